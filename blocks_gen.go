@@ -0,0 +1,107 @@
+//go:build ignore
+
+// blocks_gen writes blocks_table.go from the Unicode Character Database
+// Blocks.txt. Run it with go generate; by default it downloads the
+// Blocks.txt of the Unicode version in Go 1.25's unicode package.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "15.0.0", "Unicode version of Blocks.txt")
+	file    = flag.String("file", "", "read Blocks.txt from this file instead of unicode.org")
+	output  = flag.String("o", "blocks_table.go", "output file")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("blocks_gen: ")
+	flag.Parse()
+
+	data, err := read()
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// read returns Blocks.txt from -file or from unicode.org.
+func read() ([]byte, error) {
+	if *file != "" {
+		return os.ReadFile(*file)
+	}
+	url := "https://www.unicode.org/Public/" + *version + "/ucd/Blocks.txt"
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// generate parses data, checks it is the requested version and returns
+// the formatted table source.
+func generate(data []byte) ([]byte, error) {
+	name := "Blocks-" + *version + ".txt"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() || strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "#")) != name {
+		return nil, fmt.Errorf("input is not %s", name)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by blocks_gen.go from %s. DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&buf, "package command\n\n")
+	fmt.Fprintf(&buf, "// unicodeBlocks are the Unicode %s blocks in code point order.\n", strings.TrimSuffix(*version, ".0"))
+	fmt.Fprintf(&buf, "var unicodeBlocks = []unicodeBlock{\n")
+	var last int64 = -1
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		span, block, ok := strings.Cut(line, ";")
+		lo, hi, ok2 := strings.Cut(strings.TrimSpace(span), "..")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("malformed line %q", scanner.Text())
+		}
+		first, err := strconv.ParseInt(lo, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		end, err := strconv.ParseInt(hi, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		if first <= last || end < first {
+			return nil, fmt.Errorf("block %s..%s out of order", lo, hi)
+		}
+		last = end
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %q},\n", first, end, strings.TrimSpace(block))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// charClass is a Unicode general category group.
+type charClass int
+
+const (
+	classLetter charClass = iota
+	classMark
+	classNumber
+	classPunctuation
+	classSymbol
+	classSeparator
+	classControl
+	classFormat
+	classOther // private use, surrogates, unassigned and invalid bytes
+	charClassCount
+)
+
+// charClassTables are checked in order; Other is whatever none match.
+var charClassTables = [classOther]*unicode.RangeTable{
+	classLetter:      unicode.L,
+	classMark:        unicode.M,
+	classNumber:      unicode.N,
+	classPunctuation: unicode.P,
+	classSymbol:      unicode.S,
+	classSeparator:   unicode.Z,
+	classControl:     unicode.Cc,
+	classFormat:      unicode.Cf,
+}
+
+// classify returns the group of r.
+func classify(r rune) charClass {
+	for class, table := range charClassTables {
+		if unicode.Is(table, r) {
+			return charClass(class)
+		}
+	}
+	return classOther
+}
+
+// charClasses counts characters per group, summing to Chars.
+type charClasses [charClassCount]int
+
+// add counts the characters of line. In the C locale every byte is a
+// character, and bytes above 0x7F are Other.
+func (c *charClasses) add(line string, locale LocaleFlag) {
+	if locale == LocaleC {
+		for i := 0; i < len(line); i++ {
+			if line[i] < utf8.RuneSelf {
+				c[classify(rune(line[i]))]++
+			} else {
+				c[classOther]++
+			}
+		}
+		return
+	}
+	for len(line) > 0 {
+		r, size := utf8.DecodeRuneInString(line)
+		line = line[size:]
+		if r == utf8.RuneError && size == 1 {
+			c[classOther]++
+			continue
+		}
+		c[classify(r)]++
+	}
+}
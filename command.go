@@ -1,8 +1,11 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	gloo "github.com/gloo-foo/framework"
@@ -15,45 +18,411 @@ func Wc(parameters ...any) gloo.Command {
 }
 
 func (p command) Executor() gloo.CommandExecutor {
+	if p.Flags.SLOC {
+		return p.slocExecutor()
+	}
+	if p.Flags.GoMetrics {
+		return p.goMetricsExecutor()
+	}
 	return gloo.Inputs[gloo.File, flags](p).Wrap(
-		gloo.AccumulateAndOutput(func(lines []string, stdout io.Writer) error {
-		var lineCount, wordCount, charCount, byteCount, maxLength int
+		gloo.RawCommand(func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer) (err error) {
+			c, err := newCounts(p.Flags)
+			if err != nil {
+				return err
+			}
+			defer func() { err = errors.Join(err, c.close()) }()
+			scanner, records := newRecordScanner(stdin, p.Flags)
+			for scanner.Scan() {
+				if err := c.add(scanner.Text(), records.last); err != nil {
+					return err
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			c.end(records.unterminated)
+
+			return c.write(stdout)
+		}).Executor(),
+	)
+}
 
-		for _, line := range lines {
-			lineCount++
-			charCount += len([]rune(line))
-			byteCount += len(line) + 1 // +1 for newline
-			wordCount += len(strings.Fields(line))
+// counts accumulates the totals for a single run.
+type counts struct {
+	flags    flags
+	filter   *lineFilter
+	locale   LocaleFlag
+	splitter WordSplitter
+	prose    *prose
+	top      *frequencies
 
-			if len(line) > maxLength {
-				maxLength = len(line)
+	distinctLines, distinctWords *distinct
+	lineSketch, wordSketch       *Sketch
+	patterns                     *patterns
+	hygiene                      *hygiene
+	lengths                      *lengthStats
+	wordLengths                  *wordLengths
+	classes                      *charClasses
+	scripts, blocks              *charTally
+	hidden                       *hiddenChars
+	findings                     *findings
+
+	records, lineCount, emptyLines, whitespaceLines, wordCount, syllableCount, charCount, graphemeCount, byteCount, maxLength, maxLengthLine, overLimit int
+}
+
+func newCounts(f flags) (*counts, error) {
+	locale := f.locale()
+	c := &counts{
+		flags:    f,
+		locale:   locale,
+		splitter: f.wordSplitter(locale),
+	}
+	if bool(f.Verbose) {
+		c.findings = &findings{}
+	}
+	if bool(f.MinLength) || bool(f.MeanLength) || len(f.LengthPercentiles) > 0 || f.LengthHistogram > 0 {
+		for _, p := range f.LengthPercentiles {
+			if p <= 0 || p > 100 {
+				return nil, fmt.Errorf("length percentile %g out of range (0, 100]", p)
 			}
 		}
+		c.lengths = newLengthStats()
+	}
+	if bool(f.MeanWordLength) || bool(f.LongestWord) || f.WordLengthHistogram > 0 {
+		c.wordLengths = newWordLengths()
+	}
+	if bool(f.CharClasses) {
+		c.classes = &charClasses{}
+	}
+	if bool(f.Scripts) {
+		c.scripts = newCharTally("script", scriptOf, unknownScript)
+	}
+	if bool(f.Blocks) {
+		c.blocks = newCharTally("block", blockOf, noBlock)
+	}
+	if bool(f.ZeroWidthChars) || bool(f.StrayBOMs) || bool(f.BidiControls) {
+		c.hidden = &hiddenChars{flags: f}
+	}
+	if bool(f.WhitespaceHygiene) {
+		c.hygiene = &hygiene{}
+	}
+	if bool(f.Sentences) || bool(f.Paragraphs) || f.SentenceLimit > 0 || f.readability() {
+		c.prose = newProse(f)
+	}
+	if bool(f.UniqueLines) || bool(f.DuplicateLines) {
+		c.distinctLines = newDistinct(int(f.SpillAfter), string(f.SpillDir))
+	}
+	if bool(f.UniqueWords) {
+		c.distinctWords = newDistinct(int(f.SpillAfter), string(f.SpillDir))
+	}
+	var err error
+	if c.filter, err = newLineFilter(f); err != nil {
+		return nil, err
+	}
+	if c.lineSketch, err = f.sketch(f.LineSketch.Sketch, bool(f.ApproxUniqueLines)); err != nil {
+		return nil, err
+	}
+	if c.wordSketch, err = f.sketch(f.WordSketch.Sketch, bool(f.ApproxUniqueWords)); err != nil {
+		return nil, err
+	}
+	if len(f.Patterns) > 0 {
+		if c.patterns, err = newPatterns(f.Patterns); err != nil {
+			return nil, err
+		}
+	}
+	if f.TopWords != 0 {
+		top, err := newFrequencies(f)
+		if err != nil {
+			return nil, err
+		}
+		c.top = top
+	}
+	return c, nil
+}
 
-		// Output based on flags (default: all)
-		showAll := !bool(p.Flags.Lines) && !bool(p.Flags.Words) &&
-		           !bool(p.Flags.Chars) && !bool(p.Flags.Bytes) &&
-		           !bool(p.Flags.MaxLength)
+// add counts one record; terminator is the byte length of what ended it.
+func (c *counts) add(line string, terminator int) error {
+	c.records++
+	line = c.flags.Normalization.normalize(line)
+	if c.filter != nil && !c.filter.keep(line) {
+		return nil
+	}
 
-		var output string
-		if bool(p.Flags.Lines) || showAll {
-			output += fmt.Sprintf("%7d ", lineCount)
+	c.lineCount++
+	switch {
+	case line == "":
+		c.emptyLines++
+	case c.locale.isBlank(line):
+		c.whitespaceLines++
+	}
+	c.charCount += c.locale.countChars(line)
+	if c.classes != nil {
+		c.classes.add(line, c.locale)
+	}
+	if c.scripts != nil {
+		c.scripts.add(line, c.locale)
+	}
+	if c.blocks != nil {
+		c.blocks.add(line, c.locale)
+	}
+	if c.hidden != nil {
+		c.hidden.add(c.records, line, c.findings)
+	}
+	if bool(c.flags.Graphemes) {
+		c.graphemeCount += c.locale.countGraphemes(line)
+	}
+	c.byteCount += len(line) + terminator
+	words := c.splitter.Split(line)
+	c.wordCount += len(words)
+	if c.top != nil {
+		c.top.add(words)
+	}
+	if c.wordLengths != nil {
+		c.wordLengths.add(c.records, words, c.locale, int(c.flags.WordLengthHistogram))
+	}
+	if bool(c.flags.Syllables) || c.flags.readability() {
+		for _, word := range words {
+			c.syllableCount += syllables(word)
 		}
-		if bool(p.Flags.Words) || showAll {
-			output += fmt.Sprintf("%7d ", wordCount)
+	}
+
+	length := c.flags.LengthUnit.measure(line, c.locale)
+	if length > c.maxLength || c.maxLengthLine == 0 {
+		c.maxLength, c.maxLengthLine = length, c.records
+	}
+	if c.lengths != nil {
+		c.lengths.add(length, int(c.flags.LengthHistogram))
+	}
+	if c.flags.LineLimit > 0 && length > int(c.flags.LineLimit) {
+		c.overLimit++
+		c.findings.add(c.records, 0, fmt.Sprintf("line length %d exceeds %d", length, c.flags.LineLimit))
+	}
+
+	if c.prose != nil {
+		c.prose.add(line)
+	}
+	if c.hygiene != nil {
+		c.hygiene.add(c.records, line, c.findings)
+	}
+
+	if c.distinctLines != nil {
+		if err := c.distinctLines.add(line); err != nil {
+			return err
 		}
-		if bool(p.Flags.Chars) {
-			output += fmt.Sprintf("%7d ", charCount)
+	}
+	if c.patterns != nil {
+		c.patterns.add(line)
+	}
+	if c.lineSketch != nil {
+		c.lineSketch.Add(line)
+	}
+	if c.wordSketch != nil {
+		for _, word := range words {
+			c.wordSketch.Add(word)
 		}
-		if bool(p.Flags.Bytes) || showAll {
-			output += fmt.Sprintf("%7d ", byteCount)
+	}
+	if c.distinctWords != nil {
+		for _, word := range words {
+			if err := c.distinctWords.add(word); err != nil {
+				return err
+			}
 		}
-		if bool(p.Flags.MaxLength) {
-			output += fmt.Sprintf("%7d ", maxLength)
+	}
+	return nil
+}
+
+// end finishes checks that depend on how the input ended.
+func (c *counts) end(unterminated bool) {
+	if c.hygiene != nil {
+		c.hygiene.end(c.records, unterminated, c.findings)
+	}
+}
+
+// close releases temporary files held by the distinct counters.
+func (c *counts) close() error {
+	var errs []error
+	for _, d := range []*distinct{c.distinctLines, c.distinctWords} {
+		if d != nil {
+			errs = append(errs, d.close())
 		}
+	}
+	return errors.Join(errs...)
+}
+
+// column is one count in the output line.
+type column struct {
+	selected bool // requested by a flag
+	standard bool // shown when no column is requested, like wc -lwc
+	value    string
+}
 
-		_, err := fmt.Fprintln(stdout, strings.TrimSpace(output))
+// count formats an integer column value.
+func count(n int) string { return strconv.Itoa(n) }
+
+// columns lists every count in output order.
+func (c *counts) columns() ([]column, error) {
+	var sentences, paragraphs, longParagraphs int
+	if c.prose != nil {
+		sentences, paragraphs, longParagraphs = c.prose.sentences, c.prose.paragraphs, c.prose.longParagraphs
+	}
+	words, syllables := c.wordCount, c.syllableCount
+
+	var uniqueLines, duplicateLines, uniqueWords int
+	var err error
+	if c.distinctLines != nil {
+		if uniqueLines, duplicateLines, err = c.distinctLines.result(); err != nil {
+			return nil, err
+		}
+	}
+	if c.distinctWords != nil {
+		if uniqueWords, _, err = c.distinctWords.result(); err != nil {
+			return nil, err
+		}
+	}
+
+	approxLines, approxLinesError := approximate(c.lineSketch)
+	approxWords, approxWordsError := approximate(c.wordSketch)
+	var classes charClasses
+	if c.classes != nil {
+		classes = *c.classes
+	}
+	var hidden hiddenChars
+	if c.hidden != nil {
+		hidden = *c.hidden
+	}
+	wordLengths := c.wordLengths
+	if wordLengths == nil {
+		wordLengths = newWordLengths()
+	}
+
+	columns := []column{
+		{bool(c.flags.Lines), true, count(c.lineCount)},
+		{bool(c.flags.UniqueLines), false, count(uniqueLines)},
+		{bool(c.flags.DuplicateLines), false, count(duplicateLines)},
+		{bool(c.flags.ApproxUniqueLines), false, approxLines},
+		{bool(c.flags.ApproxUniqueLines), false, approxLinesError},
+		{bool(c.flags.EmptyLines), false, count(c.emptyLines)},
+		{bool(c.flags.WhitespaceLines), false, count(c.whitespaceLines)},
+		{bool(c.flags.NonBlankLines), false, count(c.lineCount - c.emptyLines - c.whitespaceLines)},
+		{bool(c.flags.Words), true, count(words)},
+		{bool(c.flags.UniqueWords), false, count(uniqueWords)},
+		{bool(c.flags.ApproxUniqueWords), false, approxWords},
+		{bool(c.flags.ApproxUniqueWords), false, approxWordsError},
+		{bool(c.flags.MeanWordLength), false, score(wordLengths.stats.mean())},
+		{bool(c.flags.LongestWord), false, count(wordLengths.longestLength)},
+		{bool(c.flags.LongestWord), false, count(wordLengths.longestLine)},
+		{bool(c.flags.LongestWord), false, wordLengths.quoted()},
+		{bool(c.flags.Sentences), false, count(sentences)},
+		{bool(c.flags.Paragraphs), false, count(paragraphs)},
+		{c.flags.SentenceLimit > 0, false, count(longParagraphs)},
+		{bool(c.flags.Syllables), false, count(syllables)},
+		{bool(c.flags.ReadingEase), false, score(readingEase(words, sentences, syllables))},
+		{bool(c.flags.GradeLevel), false, score(gradeLevel(words, sentences, syllables))},
+		{bool(c.flags.ReadingTime), false, score(readingTime(words, c.flags.WordsPerMinute))},
+		{bool(c.flags.Chars), false, count(c.charCount)},
+		{bool(c.flags.CharClasses), false, count(classes[classLetter])},
+		{bool(c.flags.CharClasses), false, count(classes[classMark])},
+		{bool(c.flags.CharClasses), false, count(classes[classNumber])},
+		{bool(c.flags.CharClasses), false, count(classes[classPunctuation])},
+		{bool(c.flags.CharClasses), false, count(classes[classSymbol])},
+		{bool(c.flags.CharClasses), false, count(classes[classSeparator])},
+		{bool(c.flags.CharClasses), false, count(classes[classControl])},
+		{bool(c.flags.CharClasses), false, count(classes[classFormat])},
+		{bool(c.flags.CharClasses), false, count(classes[classOther])},
+		{bool(c.flags.ZeroWidthChars), false, count(hidden.zeroWidth)},
+		{bool(c.flags.StrayBOMs), false, count(hidden.strayBOMs)},
+		{bool(c.flags.BidiControls), false, count(hidden.bidi)},
+		{bool(c.flags.Graphemes), false, count(c.graphemeCount)},
+		{bool(c.flags.Bytes), true, count(c.byteCount)},
+		{bool(c.flags.MaxLength), false, count(c.maxLength)},
+		{bool(c.flags.MaxLengthLine), false, count(c.maxLengthLine)},
+		{c.flags.LineLimit > 0, false, count(c.overLimit)},
+	}
+	if l := c.lengths; l != nil {
+		columns = append(columns,
+			column{bool(c.flags.MinLength), false, count(l.min)},
+			column{bool(c.flags.MeanLength), false, score(l.mean())},
+		)
+		for _, p := range c.flags.LengthPercentiles {
+			columns = append(columns, column{true, false, count(l.percentile(p))})
+		}
+	}
+	if h := c.hygiene; h != nil {
+		columns = append(columns,
+			column{true, false, count(h.trailing)},
+			column{true, false, count(h.tabs)},
+			column{true, false, count(h.spaces)},
+			column{true, false, count(h.mixed)},
+			column{true, false, flag(h.missingNewline)},
+		)
+	}
+	if c.patterns != nil {
+		for i := range c.patterns.lines {
+			columns = append(columns,
+				column{true, false, count(c.patterns.lines[i])},
+				column{true, false, count(c.patterns.matches[i])},
+			)
+		}
+	}
+	return columns, nil
+}
+
+// write prints the selected counts on one line, after any Verbose
+// findings and before any histograms and Scripts and Blocks tables, or the
+// TopWords table.
+func (c *counts) write(stdout io.Writer) error {
+	if c.top != nil {
+		return c.top.write(stdout)
+	}
+	if c.prose != nil {
+		c.prose.flush()
+	}
+
+	columns, err := c.columns()
+	if err != nil {
 		return err
-	}).Executor(),
-	)
+	}
+	if c.findings != nil {
+		if err := c.findings.write(stdout); err != nil {
+			return err
+		}
+	}
+
+	// Output based on flags (default: lines, words, bytes)
+	showAll := true
+	for _, col := range columns {
+		if col.selected {
+			showAll = false
+		}
+	}
+
+	var output string
+	for _, col := range columns {
+		if col.selected || (showAll && col.standard) {
+			output += fmt.Sprintf("%7s ", col.value)
+		}
+	}
+
+	if _, err := fmt.Fprintln(stdout, strings.TrimSpace(output)); err != nil {
+		return err
+	}
+	if c.flags.LengthHistogram > 0 {
+		if err := c.lengths.writeHistogram(stdout, int(c.flags.LengthHistogram)); err != nil {
+			return err
+		}
+	}
+	if c.flags.WordLengthHistogram > 0 {
+		if err := c.wordLengths.stats.writeHistogram(stdout, int(c.flags.WordLengthHistogram)); err != nil {
+			return err
+		}
+	}
+	if c.scripts != nil {
+		if err := c.scripts.write(stdout); err != nil {
+			return err
+		}
+	}
+	if c.blocks != nil {
+		return c.blocks.write(stdout)
+	}
+	return nil
 }
@@ -1,10 +1,16 @@
 package command_test
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/gloo-foo/testable/assertion"
 	"github.com/gloo-foo/testable/run"
@@ -668,3 +674,1538 @@ func TestWc_OutputFormat_SingleFlag(t *testing.T) {
 	assertion.NoError(t, err)
 }
 
+// ==============================================================================
+// Test Locale Modes
+// ==============================================================================
+
+func TestWc_LocaleC_CharsEqualBytes(t *testing.T) {
+	result := run.Command(command.Wc(command.Chars, command.LocaleC)).
+		WithStdinLines("日本語").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "9", "9 chars in C locale")
+}
+
+func TestWc_LocaleC_NBSPIsNotSeparator(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.LocaleC)).
+		WithStdinLines("hello\u00a0world foo").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "2", "NBSP joins words in C locale")
+}
+
+func TestWc_LocaleUTF8_NBSPIsSeparator(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.LocaleUTF8)).
+		WithStdinLines("hello\u00a0world foo").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "3", "NBSP separates words in UTF-8 locale")
+}
+
+func TestWc_EnvLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		lcAll    string
+		lcCtype  string
+		lang     string
+		expected string
+	}{
+		{"unset is C", "", "", "", "9"},
+		{"LANG UTF-8", "", "", "en_US.UTF-8", "3"},
+		{"LC_CTYPE beats LANG", "", "C", "en_US.UTF-8", "9"},
+		{"LC_ALL beats LC_CTYPE", "C.utf8", "POSIX", "", "3"},
+		{"non UTF-8 codeset", "de_DE.ISO-8859-1", "", "", "9"},
+		{"modifier", "", "", "sr_RS.UTF-8@latin", "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_CTYPE", tt.lcCtype)
+			t.Setenv("LANG", tt.lang)
+
+			result := run.Command(command.Wc(command.Chars, command.EnvLocale)).
+				WithStdinLines("日本語").
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "chars")
+		})
+	}
+}
+
+// ==============================================================================
+// Test Line Terminators
+// ==============================================================================
+
+func TestWc_Terminators(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		terminator command.TerminatorFlag
+		expected   string // lines, bytes, max length
+	}{
+		{"default LF", "ab\ncde\n", "", "2 7 3"},
+		{"LF keeps CR", "ab\r\ncde\r\n", command.LF, "2 9 4"},
+		{"CRLF", "ab\r\ncde\r\n", command.CRLF, "2 9 3"},
+		{"CR only", "ab\rcde\r", command.CR, "2 7 3"},
+		{"NUL", "./a\x00./bc\x00", command.NUL, "2 9 4"},
+		{"custom", "ab--cde--", command.Terminator("--"), "2 9 3"},
+		{"unterminated last record", "ab\x00cde", command.NUL, "2 6 3"},
+		{"unterminated LF", "ab\ncde", "", "2 6 3"},
+		{"unterminated CRLF", "ab\r\ncde", command.CRLF, "2 7 3"},
+		{"empty records", "\r\r\r", command.CR, "3 3 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Quick(command.Wc(
+				strings.NewReader(tt.input),
+				tt.terminator,
+				command.Lines,
+				command.Bytes,
+				command.MaxLength,
+			))
+
+			assertion.NoError(t, result.Err)
+			output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+			assertion.Equal(t, output, tt.expected, "lines bytes max")
+		})
+	}
+}
+
+func TestWc_LongRecord(t *testing.T) {
+	long := strings.Repeat("x", 100000)
+
+	result := run.Quick(command.Wc(command.Lines, command.Bytes, command.MaxLength,
+		strings.NewReader("short\n"+long+"\n")))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 100007 100000", "records over 64 KiB are read whole")
+}
+
+func TestWc_Terminator_NULKeepsNewlines(t *testing.T) {
+	result := run.Quick(command.Wc(
+		strings.NewReader("one\ntwo\x00three\x00"),
+		command.NUL,
+		command.Lines,
+		command.Words,
+	))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 3", "two records, three words")
+}
+
+// ==============================================================================
+// Test Unicode Line Breaks
+// ==============================================================================
+
+func TestWc_UnicodeLineBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string // lines, bytes, max length
+	}{
+		{"LF", "ab\ncde\n", "2 7 3"},
+		{"CRLF is one break", "ab\r\ncde\r\n", "2 9 3"},
+		{"CR", "ab\rcde\r", "2 7 3"},
+		{"NEL", "ab\u0085cde\u0085", "2 9 3"},
+		{"line separator", "ab\u2028cde\u2028", "2 11 3"},
+		{"paragraph separator", "ab\u2029cde", "2 8 3"},
+		{"VT and FF", "a\vb\fc\n", "3 6 1"},
+		{"CR CR", "a\r\rb\r", "3 5 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Quick(command.Wc(
+				strings.NewReader(tt.input),
+				command.UnicodeLineBreaks,
+				command.Lines,
+				command.Bytes,
+				command.MaxLength,
+			))
+
+			assertion.NoError(t, result.Err)
+			output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+			assertion.Equal(t, output, tt.expected, "lines bytes max")
+		})
+	}
+}
+
+func TestWc_UnicodeLineBreaks_DefaultIsNewlineOnly(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines)).
+		WithStdinLines("ab\u2028cd\u0085ef").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "1", "one line")
+}
+
+// ==============================================================================
+// Test Grapheme Counting
+// ==============================================================================
+
+func TestWc_Graphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string // chars, graphemes
+	}{
+		{"ASCII", "hello", "5 5"},
+		{"combining accent", "e\u0301", "2 1"},
+		{"precomposed", "\u00e9", "1 1"},
+		{"family emoji", "\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466", "7 1"},
+		{"flag", "\U0001F1EF\U0001F1F5", "2 1"},
+		{"two flags", "\U0001F1EF\U0001F1F5\U0001F1EB\U0001F1F7", "4 2"},
+		{"skin tone", "\U0001F44D\U0001F3FD", "2 1"},
+		{"hangul jamo", "\u1100\u1161\u11a8", "3 1"},
+		{"devanagari", "\u0915\u094d\u0937\u093f", "4 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Command(command.Wc(command.Chars, command.Graphemes)).
+				WithStdinLines(tt.input).
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+			assertion.Equal(t, output, tt.expected, "chars graphemes")
+		})
+	}
+}
+
+func TestWc_Graphemes_AcrossLines(t *testing.T) {
+	result := run.Command(command.Wc(command.Graphemes)).
+		WithStdinLines("e\u0301", "\U0001F1EF\U0001F1F5").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "2", "newlines are not clusters")
+}
+
+func TestWc_Graphemes_LocaleC(t *testing.T) {
+	result := run.Command(command.Wc(command.Graphemes, command.LocaleC)).
+		WithStdinLines("e\u0301").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "3", "bytes in C locale")
+}
+
+// ==============================================================================
+// Test Unicode Normalization
+// ==============================================================================
+
+func TestWc_Normalization(t *testing.T) {
+	composed := "caf\u00e9 \ufb01"    // precomposed e-acute, fi ligature
+	decomposed := "cafe\u0301 \ufb01" // e plus combining acute, fi ligature
+
+	tests := []struct {
+		name     string
+		form     command.NormalizationFlag
+		expected string // chars, bytes
+	}{
+		{"NFC", command.NFC, "6 10"},
+		{"NFD", command.NFD, "7 11"},
+		{"NFKC", command.NFKC, "7 9"},
+		{"NFKD", command.NFKD, "8 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, input := range []string{composed, decomposed} {
+				result := run.Command(command.Wc(command.Chars, command.Bytes, tt.form)).
+					WithStdinLines(input).
+					Run()
+
+				assertion.NoError(t, result.Err)
+				output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+				assertion.Equal(t, output, tt.expected, "chars bytes")
+			}
+		})
+	}
+}
+
+func TestWc_Normalization_DefaultLeavesInputAlone(t *testing.T) {
+	result := run.Command(command.Wc(command.Chars)).
+		WithStdinLines("cafe\u0301").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "5", "five runes")
+}
+
+// ==============================================================================
+// Test Word Splitters
+// ==============================================================================
+
+func TestWc_WordSplitters(t *testing.T) {
+	tests := []struct {
+		name     string
+		splitter command.WordSplitterFlag
+		expected string
+	}{
+		{"whitespace", command.WhitespaceWords, "3"},
+		{"alphanumeric", command.AlphanumericWords, "5"},
+		{"token pattern", command.TokenPattern(regexp.MustCompile(`[a-z]+`)), "5"},
+		{"separator set", command.SeparatorSet(" ,"), "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Command(command.Wc(command.Words, tt.splitter)).
+				WithStdinLines("hello,world foo-bar v2").
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "words")
+		})
+	}
+}
+
+func TestWc_WordSplitter_Custom(t *testing.T) {
+	commaFields := command.WordSplitterFunc(func(line string) []string {
+		return strings.Split(line, ",")
+	})
+
+	result := run.Command(command.Wc(command.Words, command.SplitWords(commaFields))).
+		WithStdinLines("a,b,,c", "d").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "5", "splitter decides what counts")
+}
+
+func TestWc_WordSplitter_OverridesLocale(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.LocaleC, command.WhitespaceWords)).
+		WithStdinLines("hello\u00a0world").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "2", "explicit splitter wins")
+}
+
+// ==============================================================================
+// Test Unicode Word Boundaries
+// ==============================================================================
+
+func TestWc_UnicodeWordBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "hello world", "2"},
+		{"punctuation only tokens", "wait -- what ?!", "2"},
+		{"contraction", "don't stop", "2"},
+		{"numbers with separators", "pi is 3.14 and 1,000 is big", "7"},
+		{"no spaces around comma", "hello,world", "2"},
+		{"hyphenated", "foo-bar", "2"},
+		{"ideographs", "日本語", "3"},
+		{"katakana", "カタカナ", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Command(command.Wc(command.Words, command.UnicodeWords)).
+				WithStdinLines(tt.input).
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "words")
+		})
+	}
+}
+
+// TestWc_UnicodeWordBoundaries_Conformance checks UnicodeWords against the Unicode
+// word-break test data, keeping only segments with a letter or number.
+func TestWc_UnicodeWordBoundaries_Conformance(t *testing.T) {
+	file, err := os.Open("testdata/WordBreakTest.txt")
+	assertion.NoError(t, err)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var input strings.Builder
+		var expected []string
+		var segment strings.Builder
+		for _, field := range strings.Fields(line) {
+			switch field {
+			case "÷":
+				if segment.Len() > 0 && strings.IndexFunc(segment.String(), func(r rune) bool {
+					return unicode.IsLetter(r) || unicode.IsNumber(r)
+				}) >= 0 {
+					expected = append(expected, segment.String())
+				}
+				segment.Reset()
+			case "×":
+			default:
+				code, err := strconv.ParseUint(field, 16, 32)
+				assertion.NoError(t, err)
+				input.WriteRune(rune(code))
+				segment.WriteRune(rune(code))
+			}
+		}
+
+		got := command.UnicodeWords.Split(input.String())
+		assertion.Equal(t, fmt.Sprintf("%q", got), fmt.Sprintf("%q", expected),
+			fmt.Sprintf("line %d: %s", lineNum, line))
+	}
+	assertion.NoError(t, scanner.Err())
+}
+
+// ==============================================================================
+// Test Dictionary Segmentation
+// ==============================================================================
+
+func TestWc_DictionaryWords(t *testing.T) {
+	dict := command.NewDictionary(
+		"我们", "是", "中国", "中国人",
+		"私", "は", "コーヒー", "が", "好き", "です",
+		"สวัสดี", "ครับ",
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"chinese longest match", "我们是中国人", "3"},
+		{"japanese mixed scripts", "私はコーヒーが好きです", "6"},
+		{"thai", "สวัสดีครับ", "2"},
+		{"unknown run is one word", "我们是日本人", "3"},
+		{"spaced text around", "hello 我们是中国人, world", "5"},
+		{"punctuation dropped", "我们。是", "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Command(command.Wc(command.Words, command.DictionaryWords(dict))).
+				WithStdinLines(tt.input).
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "words")
+		})
+	}
+}
+
+func TestWc_DictionaryWords_Nil(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.DictionaryWords(nil))).
+		WithStdinLines("hello \u6211\u4eec\u662f world").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "3", "unspaced run is one unknown word")
+}
+
+func TestWc_LoadDictionary(t *testing.T) {
+	dict, err := command.LoadDictionary(strings.NewReader(
+		"# word frequency\n世界 120\n\nこんにちは 80\n",
+	))
+	assertion.NoError(t, err)
+
+	result := run.Command(command.Wc(command.Words, command.DictionaryWords(dict))).
+		WithStdinLines("こんにちは世界").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "2", "two words")
+}
+
+// ==============================================================================
+// Test Sentences and Paragraphs
+// ==============================================================================
+
+func TestWc_Paragraphs(t *testing.T) {
+	result := run.Command(command.Wc(command.Paragraphs)).
+		WithStdinLines("", "first para", "continues here", "", "  ", "second", "", "", "third").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "3", "three paragraphs")
+}
+
+func TestWc_Sentences_Unicode(t *testing.T) {
+	result := run.Command(command.Wc(command.Sentences)).
+		WithStdinLines(
+			"This is one. This sentence",
+			"spans two lines! Is this the third?",
+			"",
+			"Fourth in a new paragraph",
+		).Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "4", "four sentences")
+}
+
+func TestWc_Sentences_Punctuation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		options  []any
+		expected string
+	}{
+		{"simple", "One. Two! Three?", nil, "3"},
+		{"default abbreviations", "Ask Dr. Smith, e.g. today. Done.", nil, "2"},
+		{"quoted end", `He said "stop." Then left.`, nil, "2"},
+		{"no trailing punctuation", "One. Two", nil, "2"},
+		{"decimal stays", "Pi is 3.14 exactly.", nil, "1"},
+		{"ellipsis", "Wait... what?", nil, "2"},
+		{"cjk full stop", "今天很好。明天见。", nil, "2"},
+		{"custom abbreviations", "See Fig. 2. Done.", []any{command.Abbreviations("Dr.")}, "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := append([]any{command.Sentences, command.PunctuationSentences}, tt.options...)
+			result := run.Command(command.Wc(params...)).
+				WithStdinLines(tt.input).
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "sentences")
+		})
+	}
+}
+
+func TestWc_SentenceLimit(t *testing.T) {
+	result := run.Command(command.Wc(command.Sentences, command.Paragraphs, command.SentenceLimit(2))).
+		WithStdinLines(
+			"One. Two.",
+			"",
+			"One. Two. Three.",
+			"",
+			"One. Two. Three. Four.",
+		).Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "9 3 2", "sentences, paragraphs, long paragraphs")
+}
+
+func TestWc_ProseColumnOrder(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.Words, command.Sentences, command.Paragraphs, command.Bytes)).
+		WithStdinLines("Hi there. Bye.").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "1 3 2 1 15", "lines words sentences paragraphs bytes")
+}
+
+// ==============================================================================
+// Test Readability Metrics
+// ==============================================================================
+
+func TestWc_Syllables(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"cat", "1"},
+		{"make", "1"},
+		{"table", "2"},
+		{"readability", "5"},
+		{"beautiful", "3"},
+		{"the", "1"},
+		{"42", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			result := run.Command(command.Wc(command.Syllables)).
+				WithStdinLines(tt.word).
+				Run()
+
+			assertion.NoError(t, result.Err)
+			output := strings.TrimSpace(result.Stdout[0])
+			assertion.Equal(t, output, tt.expected, "syllables")
+		})
+	}
+}
+
+func TestWc_ReadingEaseAndGrade(t *testing.T) {
+	// 8 words, 2 sentences, 8 syllables:
+	// ease  = 206.835 - 1.015*4 - 84.6*1 = 118.175
+	// grade = 0.39*4 + 11.8*1 - 15.59    = -2.23
+	result := run.Command(command.Wc(command.Words, command.Sentences, command.Syllables,
+		command.ReadingEase, command.GradeLevel)).
+		WithStdinLines("The cat sat down. The dog ran off.").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "8 2 8 118.2 -2.2", "words sentences syllables ease grade")
+}
+
+func TestWc_ReadingEase_SentencesNotSelected(t *testing.T) {
+	result := run.Command(command.Wc(command.ReadingEase)).
+		WithStdinLines("The cat sat down. The dog ran off.").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "118.2", "sentences counted implicitly")
+}
+
+func TestWc_ReadingTime(t *testing.T) {
+	words := strings.Repeat("word ", 476)
+
+	result := run.Command(command.Wc(command.ReadingTime)).
+		WithStdinLines(words).
+		Run()
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "2.0", "default 238 wpm")
+
+	result = run.Command(command.Wc(command.ReadingTime, command.WordsPerMinute(100))).
+		WithStdinLines(words).
+		Run()
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "4.8", "100 wpm")
+}
+
+func TestWc_Readability_EmptyInput(t *testing.T) {
+	result := run.Quick(command.Wc(command.ReadingEase, command.GradeLevel, command.ReadingTime))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "0.0 0.0 0.0", "no division by zero")
+}
+
+// ==============================================================================
+// Test Word Frequency
+// ==============================================================================
+
+func TestWc_TopWords(t *testing.T) {
+	result := run.Command(command.Wc(command.TopWords(2))).
+		WithStdinLines("the cat and the dog", "the cat").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 2, "two rows")
+	assertion.Equal(t, strings.Fields(result.Stdout[0]), []string{"3", "the"}, "first row")
+	assertion.Equal(t, strings.Fields(result.Stdout[1]), []string{"2", "cat"}, "second row")
+}
+
+func TestWc_TopWords_Options(t *testing.T) {
+	stopFile := t.TempDir() + "/stop.txt"
+	assertion.NoError(t, os.WriteFile(stopFile, []byte("the\nand a\n"), 0o644))
+
+	tests := []struct {
+		name     string
+		options  []any
+		expected []string
+	}{
+		{"all words", []any{command.TopWords(-1)},
+			[]string{"2 Go", "2 go", "1 Straße", "1 a", "1 go-Lang", "1 is", "1 strasse"}},
+		{"fold case", []any{command.TopWords(-1), command.FoldCase},
+			[]string{"4 go", "2 strasse", "1 a", "1 go-lang", "1 is"}},
+		{"min length", []any{command.TopWords(-1), command.FoldCase, command.MinWordLength(3)},
+			[]string{"2 strasse", "1 go-lang"}},
+		{"stop words", []any{command.TopWords(-1), command.StopWords("go", "Go")},
+			[]string{"1 Straße", "1 a", "1 go-Lang", "1 is", "1 strasse"}},
+		{"stop words file", []any{command.TopWords(-1), command.StopWordsFile(stopFile)},
+			[]string{"2 Go", "2 go", "1 Straße", "1 go-Lang", "1 is", "1 strasse"}},
+		{"least frequent", []any{command.TopWords(2), command.LeastFrequent},
+			[]string{"1 Straße", "1 a"}},
+		{"alphabetical", []any{command.TopWords(3), command.Alphabetical},
+			[]string{"2 Go", "1 Straße", "1 a"}},
+		{"splitter", []any{command.TopWords(1), command.FoldCase, command.AlphanumericWords},
+			[]string{"5 go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Command(command.Wc(tt.options...)).
+				WithStdinLines("Go go Go is a go", "Straße strasse", "go-Lang").
+				Run()
+
+			assertion.NoError(t, result.Err)
+			var rows []string
+			for _, line := range result.Stdout {
+				rows = append(rows, strings.Join(strings.Fields(line), " "))
+			}
+			assertion.Equal(t, rows, tt.expected, "rows")
+		})
+	}
+}
+
+func TestWc_TopWords_MissingStopWordsFile(t *testing.T) {
+	result := run.Command(command.Wc(command.TopWords(5), command.StopWordsFile("/nonexistent/stop.txt"))).
+		WithStdinLines("hello").
+		Run()
+
+	assertion.ErrorContains(t, result.Err, "stop.txt")
+}
+
+// ==============================================================================
+// Test Distinct Counts
+// ==============================================================================
+
+func TestWc_UniqueAndDuplicateLines(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.UniqueLines, command.DuplicateLines)).
+		WithStdinLines("a", "b", "a", "c", "b", "a", "").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "7 4 2", "lines, unique lines, duplicated lines")
+}
+
+func TestWc_UniqueWords(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.UniqueWords)).
+		WithStdinLines("the cat and the dog", "The cat").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "7 5", "words, unique words")
+}
+
+func TestWc_Distinct_Spill(t *testing.T) {
+	lines := make([]string, 0, 300)
+	for i := range 300 {
+		lines = append(lines, fmt.Sprintf("line %d", i%120))
+	}
+	dir := t.TempDir()
+
+	inMemory := run.Command(command.Wc(command.UniqueLines, command.DuplicateLines, command.UniqueWords)).
+		WithStdinLines(lines...).
+		Run()
+	spilled := run.Command(command.Wc(command.UniqueLines, command.DuplicateLines, command.UniqueWords,
+		command.SpillAfter(16), command.SpillDir(dir))).
+		WithStdinLines(lines...).
+		Run()
+
+	assertion.NoError(t, inMemory.Err)
+	assertion.NoError(t, spilled.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(inMemory.Stdout[0]), " "), "120 120 121", "in memory")
+	assertion.Equal(t, spilled.Stdout, inMemory.Stdout, "spilled matches in memory")
+
+	entries, err := os.ReadDir(dir)
+	assertion.NoError(t, err)
+	assertion.Equal(t, len(entries), 0, "run files removed")
+}
+
+func TestWc_Distinct_SpillManyRuns(t *testing.T) {
+	lines := make([]string, 0, 5000)
+	for i := range 5000 {
+		lines = append(lines, strconv.Itoa(i%3000))
+	}
+	dir := t.TempDir()
+
+	result := run.Command(command.Wc(command.UniqueLines, command.DuplicateLines,
+		command.SpillAfter(7), command.SpillDir(dir))).
+		WithStdinLines(lines...).
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "3000 2000", "hundreds of runs merged in passes")
+
+	entries, err := os.ReadDir(dir)
+	assertion.NoError(t, err)
+	assertion.Equal(t, len(entries), 0, "run files removed")
+}
+
+func TestWc_Distinct_SpillDirMissing(t *testing.T) {
+	result := run.Command(command.Wc(command.UniqueLines, command.SpillAfter(1),
+		command.SpillDir("/nonexistent/spill"))).
+		WithStdinLines("a", "b").
+		Run()
+
+	assertion.ErrorContains(t, result.Err, "/nonexistent/spill")
+}
+
+// ==============================================================================
+// Test Approximate Distinct Counts
+// ==============================================================================
+
+func TestWc_ApproxUniqueLines(t *testing.T) {
+	lines := make([]string, 0, 20000)
+	for i := range 20000 {
+		lines = append(lines, fmt.Sprintf("user-%d", i%5000))
+	}
+
+	result := run.Command(command.Wc(command.ApproxUniqueLines, command.ApproxUniqueWords)).
+		WithStdinLines(lines...).
+		Run()
+
+	assertion.NoError(t, result.Err)
+	fields := strings.Fields(result.Stdout[0])
+	assertion.Equal(t, len(fields), 4, "estimate and error for lines and words")
+
+	estimate, err := strconv.Atoi(fields[0])
+	assertion.NoError(t, err)
+	stdErr, err := strconv.Atoi(fields[1])
+	assertion.NoError(t, err)
+	assertion.Equal(t, math.Abs(float64(estimate-5000)) <= 3*float64(stdErr), true,
+		fmt.Sprintf("estimate %d ± %d", estimate, stdErr))
+	assertion.Equal(t, fields[2], fields[0], "one word per line")
+}
+
+func TestWc_ApproxUniqueLines_Precision(t *testing.T) {
+	result := run.Command(command.Wc(command.ApproxUniqueLines, command.Precision(4))).
+		WithStdinLines("a", "b", "c").
+		Run()
+	assertion.NoError(t, result.Err)
+
+	result = run.Command(command.Wc(command.ApproxUniqueLines, command.Precision(19))).
+		WithStdinLines("a").
+		Run()
+	assertion.ErrorContains(t, result.Err, "precision")
+}
+
+func TestWc_Sketch_MergeAcrossInputs(t *testing.T) {
+	encoded := make([][]byte, 0, 2)
+	for file := range 2 {
+		lines := make([]string, 0, 3000)
+		for i := range 3000 {
+			lines = append(lines, fmt.Sprintf("line-%d", file*2000+i)) // files overlap by 1000
+		}
+
+		sketch, err := command.NewSketch(12)
+		assertion.NoError(t, err)
+		result := run.Command(command.Wc(command.Lines, command.LineSketch(sketch))).
+			WithStdinLines(lines...).
+			Run()
+		assertion.NoError(t, result.Err)
+
+		data, err := sketch.MarshalBinary()
+		assertion.NoError(t, err)
+		encoded = append(encoded, data)
+	}
+
+	total, err := command.NewSketch(12)
+	assertion.NoError(t, err)
+	for _, data := range encoded {
+		var sketch command.Sketch
+		assertion.NoError(t, sketch.UnmarshalBinary(data))
+		assertion.NoError(t, total.Merge(&sketch))
+	}
+
+	result := run.Quick(command.Wc(strings.NewReader(""), command.ApproxUniqueLines, command.LineSketch(total)))
+	assertion.NoError(t, result.Err)
+	fields := strings.Fields(result.Stdout[0])
+	estimate, _ := strconv.Atoi(fields[0])
+	stdErr, _ := strconv.Atoi(fields[1])
+	assertion.Equal(t, math.Abs(float64(estimate-5000)) <= 3*float64(stdErr), true,
+		fmt.Sprintf("union estimate %d ± %d", estimate, stdErr))
+}
+
+func TestWc_Sketch_Words(t *testing.T) {
+	sketch, err := command.NewSketch(12)
+	assertion.NoError(t, err)
+
+	result := run.Command(command.Wc(command.Words, command.WordSketch(sketch))).
+		WithStdinLines("red green blue", "red green cyan").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "6", "words")
+	assertion.Equal(t, math.Round(sketch.Estimate()), 4.0, "four distinct words, not two lines")
+}
+
+func TestWc_Sketch_ZeroValue(t *testing.T) {
+	var empty command.Sketch
+	assertion.Equal(t, empty.Estimate(), 0.0, "empty estimate")
+	assertion.Equal(t, empty.Precision(), 14, "default precision")
+
+	var sketch command.Sketch
+	result := run.Command(command.Wc(command.ApproxUniqueLines, command.LineSketch(&sketch))).
+		WithStdinLines("a", "b", "a").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "2 0", "estimate and standard error")
+	assertion.Equal(t, sketch.Precision(), 14, "registers allocated on first Add")
+
+	small, _ := command.NewSketch(10)
+	small.Add("a")
+	var total command.Sketch
+	assertion.NoError(t, total.Merge(small))
+	assertion.Equal(t, total.Precision(), 10, "first merge sets precision")
+	assertion.NoError(t, total.Merge(&command.Sketch{}))
+	assertion.Equal(t, math.Round(total.Estimate()), 1.0, "merging a zero sketch adds nothing")
+}
+
+func TestWc_Sketch_Errors(t *testing.T) {
+	_, err := command.NewSketch(3)
+	assertion.ErrorContains(t, err, "out of range")
+
+	a, _ := command.NewSketch(10)
+	b, _ := command.NewSketch(11)
+	assertion.ErrorContains(t, a.Merge(b), "precision")
+	assertion.ErrorContains(t, a.Merge(nil), "nil sketch")
+
+	var sketch command.Sketch
+	assertion.ErrorContains(t, sketch.UnmarshalBinary([]byte{1, 10, 0}), "registers")
+	assertion.ErrorContains(t, sketch.UnmarshalBinary([]byte{9}), "unrecognised")
+}
+
+// ==============================================================================
+// Test Pattern Counting
+// ==============================================================================
+
+func TestWc_Pattern(t *testing.T) {
+	result := run.Command(command.Wc(command.Pattern(`err(or)?`, `^\d+$`))).
+		WithStdinLines("error: disk", "err err error", "42", "ok").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 4 1 1", "lines and matches per pattern")
+}
+
+func TestWc_Pattern_WithOtherCounts(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.FixedStrings("a.b"), command.Pattern("a.b"))).
+		WithStdinLines("a.b axb", "none").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 1 1 1 2", "lines, fixed a.b, regex a.b")
+}
+
+func TestWc_FixedStrings_AhoCorasickMatchesScan(t *testing.T) {
+	words := []string{"he", "she", "his", "hers", "aa", "a", "xyz", "s", "her"}
+	input := []string{"ushers", "aaaa", "his hers she", "nothing", "xyzxyz"}
+
+	var separate []string
+	for _, word := range words {
+		result := run.Command(command.Wc(command.FixedStrings(word))).
+			WithStdinLines(input...).
+			Run()
+		assertion.NoError(t, result.Err)
+		separate = append(separate, strings.Fields(result.Stdout[0])...)
+	}
+
+	combined := run.Command(command.Wc(command.FixedStrings(words...))).
+		WithStdinLines(input...).
+		Run()
+
+	assertion.NoError(t, combined.Err)
+	assertion.Equal(t, strings.Fields(combined.Stdout[0]), separate, "automaton agrees with strings.Count")
+	assertion.Equal(t, strings.Join(separate[8:12], " "), "1 2 1 4", "aa non-overlapping, a every occurrence")
+}
+
+func TestWc_Pattern_Errors(t *testing.T) {
+	result := run.Command(command.Wc(command.Pattern("("))).
+		WithStdinLines("x").
+		Run()
+	assertion.ErrorContains(t, result.Err, "missing closing )")
+
+	result = run.Command(command.Wc(command.FixedStrings(""))).
+		WithStdinLines("x").
+		Run()
+	assertion.ErrorContains(t, result.Err, "empty fixed string")
+}
+
+// ==============================================================================
+// Test Line Filters
+// ==============================================================================
+
+func TestWc_IgnoreLines(t *testing.T) {
+	result := run.Command(command.Wc(command.IgnoreLines(`^\s*#`, `^\s*$`))).
+		WithStdinLines("# comment", "key = value", "", "   ", "  # indented", "other = 1").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 6 22", "only config lines counted")
+}
+
+func TestWc_OnlyLines(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.OnlyLines("^ERROR", "^WARN"), command.IgnoreLines("ignored"))).
+		WithStdinLines("ERROR one", "INFO two", "WARN three", "ERROR ignored").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.TrimSpace(result.Stdout[0])
+	assertion.Equal(t, output, "2", "two matching lines")
+}
+
+func TestWc_IgnoreLines_AppliesToAllCounters(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.UniqueLines, command.Pattern("a"), command.IgnoreLines("^#"))).
+		WithStdinLines("a", "#a", "a", "b").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "3 2 2 2", "filtered before every count")
+}
+
+func TestWc_IgnoreLines_InvalidPattern(t *testing.T) {
+	result := run.Command(command.Wc(command.IgnoreLines("["))).
+		WithStdinLines("x").
+		Run()
+
+	assertion.ErrorContains(t, result.Err, "missing closing ]")
+}
+
+// ==============================================================================
+// Test SLOC
+// ==============================================================================
+
+func writeSource(t *testing.T, dir, name, source string) string {
+	t.Helper()
+	path := dir + "/" + name
+	assertion.NoError(t, os.WriteFile(path, []byte(source), 0o644))
+	return path
+}
+
+func TestWc_SLOC_PerFileAndLanguage(t *testing.T) {
+	dir := t.TempDir()
+	goFile := writeSource(t, dir, "main.go", strings.Join([]string{
+		"// Package main says hello.",
+		"package main",
+		"",
+		"/* a block",
+		"   comment */",
+		`var url = "http://example.com" // trailing`,
+		"var raw = `",
+		"/* not a comment */",
+		"`",
+		"func main() {} /* code first */",
+	}, "\n")+"\n")
+	pyFile := writeSource(t, dir, "tool.py", "# comment\nx = '#not'\n\n\"\"\"doc\n# inside\n\"\"\"\n")
+	txtFile := writeSource(t, dir, "notes.txt", "just text\n")
+
+	result := run.Quick(command.Wc(command.SLOC, goFile, pyFile, txtFile))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 5, "two files, two languages, total")
+	rows := make([]string, len(result.Stdout))
+	for i, line := range result.Stdout {
+		rows[i] = strings.Join(strings.Fields(line), " ")
+	}
+	assertion.Equal(t, rows[0], "1 1 3 6 "+goFile, "go file")
+	assertion.Equal(t, rows[1], "1 1 4 1 "+pyFile, "python file, docstring as comment")
+	assertion.Equal(t, rows[2], "1 1 3 6 Go", "go total")
+	assertion.Equal(t, rows[3], "1 1 4 1 Python", "python total")
+	assertion.Equal(t, rows[4], "2 2 7 7 total", "overall total")
+}
+
+func TestWc_SLOC_NestedComments(t *testing.T) {
+	source := "/* outer /* inner */ still comment */\nfn main() {}\n"
+
+	result := run.Quick(command.Wc(command.SLOC, command.Language("rust"), strings.NewReader(source)))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "1 0 1 1 -", "nested block closes")
+
+	result = run.Quick(command.Wc(command.SLOC, command.Language("c"), strings.NewReader(source)))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "1 0 0 2 -", "first close ends C comment, leaving code")
+}
+
+func TestWc_SLOC_PythonDocstrings(t *testing.T) {
+	source := strings.Join([]string{
+		"def f():",
+		`    """Summary.`,
+		"",
+		"    More.",
+		`    """`,
+		`    x = """not a docstring`,
+		`    still code"""`,
+		`    """One line."""`,
+		"    return x",
+	}, "\n") + "\n"
+
+	result := run.Quick(command.Wc(command.SLOC, command.Language("python"), strings.NewReader(source)))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "1 1 4 4 -", "docstrings are comments, assigned strings are code")
+}
+
+func TestWc_SLOC_Shebang(t *testing.T) {
+	result := run.Command(command.Wc(command.SLOC)).
+		WithStdinLines("#!/usr/bin/env python3", "# comment", "print('hi')").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "1 0 2 1 -", "shebang detects Python")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[1]), " "), "1 0 2 1 Python", "language row")
+}
+
+func TestWc_SLOC_UnknownLanguage(t *testing.T) {
+	result := run.Quick(command.Wc(command.SLOC, command.Language("klingon"), strings.NewReader("x\n")))
+
+	assertion.ErrorContains(t, result.Err, `unknown language "klingon"`)
+}
+
+// ==============================================================================
+// Test Go Metrics
+// ==============================================================================
+
+func TestWc_GoMetrics(t *testing.T) {
+	dir := t.TempDir()
+	source := writeSource(t, dir, "a.go", strings.Join([]string{
+		"package a",
+		"",
+		"type T struct{}",
+		"",
+		"func New() *T {",
+		"\tx := 1",
+		"\tif x > 0 {",
+		"\t\treturn &T{}",
+		"\t}",
+		"\treturn nil",
+		"}",
+		"",
+		`func (t *T) Name() string { return "t" }`,
+	}, "\n")+"\n")
+	test := writeSource(t, dir, "a_test.go", strings.Join([]string{
+		"package a_test",
+		"",
+		`import "testing"`,
+		"",
+		`func TestNew(t *testing.T) { t.Log("ok") }`,
+		"",
+		"func BenchmarkNew(b *testing.B) {}",
+		"",
+		"func helper() {}",
+		"",
+		"func Testify() {}",
+	}, "\n")+"\n")
+	generated := writeSource(t, dir, "gen.go", "// Code generated by stringer. DO NOT EDIT.\n\npackage a\n\nfunc gen() {}\n")
+	notes := writeSource(t, dir, "notes.txt", "not go\n")
+
+	result := run.Quick(command.Wc(command.GoMetrics, source, test, generated, notes))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 4, "production, test, total and generated rows")
+	rows := make([]string, len(result.Stdout))
+	for i, line := range result.Stdout {
+		rows[i] = strings.Join(strings.Fields(line), " ")
+	}
+	assertion.Equal(t, rows[0], "1 13 1 1 1 1 5 0 production", "production code")
+	assertion.Equal(t, rows[1], "1 11 1 0 4 0 1 2 test", "test code")
+	assertion.Equal(t, rows[2], "2 24 2 1 5 1 6 2 total", "generated file excluded")
+	assertion.Equal(t, rows[3], "1 5 1 0 1 0 0 0 generated", "generated file reported apart")
+}
+
+func TestWc_GoMetrics_Stdin(t *testing.T) {
+	result := run.Command(command.Wc(command.GoMetrics, command.Language("go"))).
+		WithStdinLines("package main", "", "func main() { println() }").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "1 3 1 0 1 0 1 0 total", "stdin parsed as Go")
+}
+
+func TestWc_GoMetrics_SyntaxError(t *testing.T) {
+	result := run.Quick(command.Wc(command.GoMetrics, command.Language("go"), strings.NewReader("package\n")))
+
+	assertion.ErrorContains(t, result.Err, "expected 'IDENT'")
+}
+
+// ==============================================================================
+// Test Blank Lines
+// ==============================================================================
+
+func TestWc_BlankLines(t *testing.T) {
+	result := run.Quick(command.Wc(command.EmptyLines, command.WhitespaceLines, command.NonBlankLines,
+		strings.NewReader("one\n\n \t\ntwo\n\n")))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 1 2", "empty, whitespace-only, non-blank")
+}
+
+func TestWc_BlankLines_WithLines(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.NonBlankLines, command.Words)).
+		WithStdinLines("a b", "", "c").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "3 2 3", "lines, non-blank lines, words")
+}
+
+func TestWc_WhitespaceLines_Locale(t *testing.T) {
+	input := "\u00a0\u3000\n"
+
+	result := run.Quick(command.Wc(command.WhitespaceLines, command.LocaleUTF8, strings.NewReader(input)))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "1", "Unicode spaces are blank in UTF-8")
+
+	result = run.Quick(command.Wc(command.WhitespaceLines, command.LocaleC, strings.NewReader(input)))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "0", "only ASCII spaces are blank in C")
+}
+
+// ==============================================================================
+// Test Whitespace Hygiene
+// ==============================================================================
+
+func TestWc_WhitespaceHygiene(t *testing.T) {
+	input := "func f() {\n\tx := 1 \n    y := 2\n\t  z := 3\n   \n}"
+
+	result := run.Quick(command.Wc(command.WhitespaceHygiene, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "2 1 1 1 1", "trailing, tabs, spaces, mixed, missing newline")
+}
+
+func TestWc_WhitespaceHygiene_Clean(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.WhitespaceHygiene)).
+		WithStdinLines("a", "\tb", "").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "3 0 1 0 0 0", "lines then hygiene columns")
+}
+
+func TestWc_WhitespaceHygiene_CRLF(t *testing.T) {
+	input := "x := 1 \r\n\ty := 2\r\n"
+
+	for _, terminator := range []command.TerminatorFlag{command.LF, command.CRLF} {
+		result := run.Quick(command.Wc(command.WhitespaceHygiene, terminator, strings.NewReader(input)))
+
+		assertion.NoError(t, result.Err)
+		output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+		assertion.Equal(t, output, "1 1 0 0 0", fmt.Sprintf("CR before the terminator ignored with %q", terminator))
+	}
+}
+
+func TestWc_WhitespaceHygiene_Verbose(t *testing.T) {
+	input := "ok\nbad \n\t  mixed\nend"
+
+	result := run.Quick(command.Wc(command.WhitespaceHygiene, command.Verbose, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 4, "three findings then counts")
+	assertion.Equal(t, result.Stdout[0], "2:4: trailing whitespace", "column of first trailing blank")
+	assertion.Equal(t, result.Stdout[1], "3: mixed indentation", "mixed line")
+	assertion.Equal(t, result.Stdout[2], "4: no newline at end of input", "last line")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[3]), " "), "1 0 0 1 1", "counts follow")
+}
+
+// ==============================================================================
+// Test Line Limit
+// ==============================================================================
+
+func TestWc_LineLimit(t *testing.T) {
+	result := run.Command(command.Wc(command.MaxLength, command.MaxLengthLine, command.LineLimit(5))).
+		WithStdinLines("short", "longer line", "tiny", "the longest line", "sixsix").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "16 4 3", "max length, its line, lines over limit")
+}
+
+func TestWc_LineLimit_Verbose(t *testing.T) {
+	result := run.Command(command.Wc(command.LineLimit(3), command.Verbose)).
+		WithStdinLines("abc", "abcd", "", "abcdef").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 3, "two findings then counts")
+	assertion.Equal(t, result.Stdout[0], "2: line length 4 exceeds 3", "first long line")
+	assertion.Equal(t, result.Stdout[1], "4: line length 6 exceeds 3", "second long line")
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[2]), "2", "count")
+}
+
+func TestWc_LengthUnit(t *testing.T) {
+	input := "\u65e5\u672c\u8a9e\na\tb\ncafe\u0301\n"
+	tests := []struct {
+		name string
+		unit command.LengthUnitFlag
+		want string
+	}{
+		{"bytes", command.LengthBytes, "9 1"},
+		{"runes", command.LengthRunes, "5 3"},
+		{"width", command.LengthWidth, "9 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := run.Quick(command.Wc(command.MaxLength, command.MaxLengthLine, tt.unit, strings.NewReader(input)))
+
+			assertion.NoError(t, result.Err)
+			output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+			assertion.Equal(t, output, tt.want, "longest line and where")
+		})
+	}
+}
+
+// ==============================================================================
+// Test Length Statistics
+// ==============================================================================
+
+func TestWc_LengthStatistics(t *testing.T) {
+	lines := []string{}
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, strings.Repeat("x", i))
+	}
+	result := run.Command(command.Wc(command.MinLength, command.MeanLength, command.MaxLength, command.LengthPercentiles(50, 90, 99))).
+		WithStdinLines(lines...).
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "100 1 50.5 50 90 99", "max, min, mean, p50, p90, p99")
+}
+
+func TestWc_LengthStatistics_PathologicalLine(t *testing.T) {
+	lines := make([]string, 0, 101)
+	for range 100 {
+		lines = append(lines, strings.Repeat("x", 80))
+	}
+	lines = append(lines, strings.Repeat("y", 200000))
+
+	result := run.Command(command.Wc(command.MaxLength, command.MinLength, command.LengthPercentiles(50, 99))).
+		WithStdinLines(lines...).
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "200000 80 80 80", "one huge line sets the max but not p99")
+}
+
+func TestWc_LengthHistogram(t *testing.T) {
+	result := run.Command(command.Wc(command.Lines, command.LengthHistogram(10))).
+		WithStdinLines("", "abc", "abcdefghij", "abcdefghijk", "abcdefghijklmnopqrstuvwxyz").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 4, "counts then three buckets")
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "5", "lines")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[1]), " "), "2 0-9", "short lines")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "2 10-19", "medium lines")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[3]), " "), "1 20-29", "empty buckets skipped")
+}
+
+func TestWc_LengthPercentiles_Estimated(t *testing.T) {
+	const distinct = 5000
+	var input strings.Builder
+	for i := 1; i <= distinct; i++ {
+		input.WriteString(strings.Repeat("x", i))
+		input.WriteByte('\n')
+	}
+
+	result := run.Quick(command.Wc(command.LengthPercentiles(50, 99), strings.NewReader(input.String())))
+
+	assertion.NoError(t, result.Err)
+	fields := strings.Fields(result.Stdout[0])
+	for i, want := range []float64{2500, 4950} {
+		got, err := strconv.Atoi(fields[i])
+		assertion.NoError(t, err)
+		assertion.Equal(t, math.Abs(float64(got)-want)/want <= 0.01, true,
+			fmt.Sprintf("percentile %d is %d, want %v within 1%%", i, got, want))
+	}
+}
+
+func TestWc_LengthPercentiles_OutOfRange(t *testing.T) {
+	result := run.Command(command.Wc(command.LengthPercentiles(0))).
+		WithStdinLines("x").
+		Run()
+
+	assertion.ErrorContains(t, result.Err, "out of range")
+}
+
+// ==============================================================================
+// Test Word Lengths
+// ==============================================================================
+
+func TestWc_WordLengths(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.MeanWordLength, command.LongestWord)).
+		WithStdinLines("a bb ccc", "", "dddd eeee", "ff").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, `6 2.7 4 3 "dddd"`, "words, mean, longest length, line, word")
+}
+
+func TestWc_WordLengths_SameSplitterAsWords(t *testing.T) {
+	result := run.Quick(command.Wc(command.LongestWord, command.AlphanumericWords,
+		strings.NewReader("na\u00efve-word, x\n")))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "5 1 \"na\u00efve\"", "split on the hyphen, measured in characters")
+}
+
+func TestWc_LongestWord_WithSpaces(t *testing.T) {
+	result := run.Command(command.Wc(command.LongestWord, command.SeparatorSet(","))).
+		WithStdinLines("a,new york,b").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	fields := strings.Fields(result.Stdout[0])
+	assertion.Equal(t, len(fields), 3, "the word stays one column")
+	assertion.Equal(t, fields[2], `"new\x20york"`, "space escaped")
+}
+
+func TestWc_WordLengthHistogram(t *testing.T) {
+	result := run.Command(command.Wc(command.Words, command.LengthHistogram(100), command.WordLengthHistogram(2))).
+		WithStdinLines("a bb ccc dddd").
+		Run()
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 5, "counts, line histogram, word histogram")
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "4", "words")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[1]), " "), "1 0-99", "line lengths")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "1 0-1", "one-letter words")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[3]), " "), "2 2-3", "two and three letters")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[4]), " "), "1 4-5", "four letters")
+}
+
+// ==============================================================================
+// Test Character Classes
+// ==============================================================================
+
+func TestWc_CharClasses(t *testing.T) {
+	// letters a \u00e9, mark \u0301, separators space \u00a0, numbers 7 \u216b,
+	// punctuation , !, symbols $ \u20ac, control \t \a, format \u200d \u00ad,
+	// other (private use) \ue000
+	input := "a\u00e9\u0301 7\u216b,!\u00a0$\u20ac\t\a\u200d\u00ad\ue000\n"
+
+	result := run.Quick(command.Wc(command.Chars, command.CharClasses, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "16 2 1 2 2 2 2 2 2 1", "chars then one column per class")
+}
+
+func TestWc_CharClasses_InvalidUTF8(t *testing.T) {
+	result := run.Quick(command.Wc(command.CharClasses, strings.NewReader("a\xffb\ufffd\n")))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "2 0 0 0 1 0 0 0 1",
+		"invalid byte is other, a real U+FFFD is a symbol")
+
+	result = run.Quick(command.Wc(command.CharClasses, command.LocaleC, strings.NewReader("\u00e9!\n")))
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[0]), " "), "0 0 0 1 0 0 0 0 2", "C locale bytes")
+}
+
+// ==============================================================================
+// Test Scripts
+// ==============================================================================
+
+func TestWc_Scripts(t *testing.T) {
+	input := "Hello \u041c\u0438\u0440 \u4e16\u754c\n"
+
+	result := run.Quick(command.Wc(command.Chars, command.Scripts, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 5, "counts then four scripts")
+	assertion.Equal(t, strings.TrimSpace(result.Stdout[0]), "12", "chars")
+	rows := make([]string, 0, 4)
+	for _, line := range result.Stdout[1:] {
+		rows = append(rows, strings.Join(strings.Fields(line), " "))
+	}
+	assertion.Equal(t, strings.Join(rows, "; "), "5 41.7% script Latin; 3 25.0% script Cyrillic; 2 16.7% script Common; 2 16.7% script Han",
+		"most characters first, ties by name")
+}
+
+func TestWc_Scripts_Unknown(t *testing.T) {
+	result := run.Quick(command.Wc(command.Lines, command.Scripts, strings.NewReader("a\xff\U0010fffe\ufffd\n")))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[1]), " "), "2 50.0% script Unknown", "invalid and unassigned")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "1 25.0% script Common", "a real U+FFFD")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[3]), " "), "1 25.0% script Latin", "letter")
+}
+
+func TestWc_Blocks(t *testing.T) {
+	input := "Caf\u00e9 \u041c\u0438\u0440 \u4e16\U0001F600\xff\n"
+
+	result := run.Quick(command.Wc(command.Scripts, command.Blocks, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	kinds := make([]string, 0, len(result.Stdout))
+	blocks := make([]string, 0, len(result.Stdout))
+	for _, line := range result.Stdout[1:] {
+		fields := strings.Fields(line)
+		kinds = append(kinds, fields[2])
+		if fields[2] == "block" {
+			blocks = append(blocks, strings.Join(fields, " "))
+		}
+	}
+	assertion.Equal(t, strings.Join(kinds, " "), "script script script script script block block block block block block",
+		"scripts then blocks, each row labelled")
+	assertion.Equal(t, strings.Join(blocks, "; "), "5 41.7% block Basic Latin; 3 25.0% block Cyrillic; "+
+		"1 8.3% block CJK Unified Ideographs; 1 8.3% block Emoticons; 1 8.3% block Latin-1 Supplement; 1 8.3% block No_Block",
+		"invalid byte in no block")
+}
+
+func TestWc_Blocks_Unicode15(t *testing.T) {
+	result := run.Quick(command.Wc(command.Lines, command.Blocks, strings.NewReader("\U00031350\U00011F00\n")))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[1]), " "), "1 50.0% block CJK Unified Ideographs Extension H", "Unicode 15.0 block")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "1 50.0% block Kawi", "Unicode 15.0 block")
+}
+
+// ==============================================================================
+// Test Hidden Characters
+// ==============================================================================
+
+func TestWc_HiddenChars(t *testing.T) {
+	input := "\ufeffpackage main\n" +
+		"var access = \"user\u202e \u2066// admin\u2069 \u2066\"\n" +
+		"x\u200by := 1\ufeff\n" +
+		"a\u200eb\u200fc\u061cd\n"
+
+	result := run.Quick(command.Wc(command.ZeroWidthChars, command.StrayBOMs, command.BidiControls, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	output := strings.Join(strings.Fields(result.Stdout[0]), " ")
+	assertion.Equal(t, output, "1 1 7", "zero-width, stray BOMs, bidi controls including marks")
+}
+
+func TestWc_HiddenChars_Verbose(t *testing.T) {
+	input := "\ufeffok\nab\u200bc\n\u202eevil\ufeff\n"
+
+	result := run.Quick(command.Wc(command.ZeroWidthChars, command.BidiControls, command.Verbose, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, len(result.Stdout), 3, "two findings then the counts")
+	assertion.Equal(t, result.Stdout[0], "2:3: U+200B ZERO WIDTH SPACE", "zero-width listed")
+	assertion.Equal(t, result.Stdout[1], "3:1: U+202E RIGHT-TO-LEFT OVERRIDE", "bidi listed")
+	assertion.Equal(t, strings.Join(strings.Fields(result.Stdout[2]), " "), "1 1", "stray BOM not selected")
+
+	result = run.Quick(command.Wc(command.StrayBOMs, command.Verbose, strings.NewReader(input)))
+
+	assertion.NoError(t, result.Err)
+	assertion.Equal(t, result.Stdout[0], "3:8: U+FEFF BYTE ORDER MARK not at start of input", "stray BOM listed")
+}
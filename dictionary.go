@@ -0,0 +1,144 @@
+package command
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dictionary is a word list used to segment scripts written without spaces.
+type Dictionary struct {
+	words   map[string]bool
+	longest int // longest entry, in runes
+}
+
+// NewDictionary returns a Dictionary containing words.
+func NewDictionary(words ...string) *Dictionary {
+	d := &Dictionary{words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		d.add(word)
+	}
+	return d
+}
+
+// LoadDictionary reads a dictionary with one word per line. Only the first
+// field of each line is used, so frequency lists work as-is; blank lines and
+// lines starting with '#' are skipped.
+func LoadDictionary(r io.Reader) (*Dictionary, error) {
+	d := NewDictionary()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		d.add(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dictionary) add(word string) {
+	if word == "" {
+		return
+	}
+	d.words[word] = true
+	if n := utf8.RuneCountInString(word); n > d.longest {
+		d.longest = n
+	}
+}
+
+// DictionaryWords segments runs of Han, Hiragana, Katakana, Thai, Lao,
+// Khmer and Myanmar text by forward maximum matching against d. Characters
+// not covered by the dictionary are grouped into a single unknown word.
+// Text in other scripts is split like UnicodeWords. A nil d is an empty
+// dictionary.
+func DictionaryWords(d *Dictionary) WordSplitterFlag {
+	if d == nil {
+		d = NewDictionary()
+	}
+	return SplitWords(WordSplitterFunc(func(line string) []string {
+		var words []string
+		for len(line) > 0 {
+			if end := unspacedPrefix(line); end > 0 {
+				words = append(words, d.segment(line[:end])...)
+				line = line[end:]
+				continue
+			}
+			end := strings.IndexFunc(line, isUnspaced)
+			if end < 0 {
+				end = len(line)
+			}
+			words = append(words, UnicodeWords.Split(line[:end])...)
+			line = line[end:]
+		}
+		return words
+	}))
+}
+
+// segment splits a run of unspaced text using forward maximum matching.
+func (d *Dictionary) segment(run string) []string {
+	var words []string
+	unknown := 0 // start of pending unmatched text
+	for i := 0; i < len(run); {
+		if n := d.match(run[i:]); n > 0 {
+			if unknown < i {
+				words = append(words, run[unknown:i])
+			}
+			words = append(words, run[i:i+n])
+			i += n
+			unknown = i
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(run[i:])
+		i += size
+	}
+	if unknown < len(run) {
+		words = append(words, run[unknown:])
+	}
+	return words
+}
+
+// match returns the byte length of the longest dictionary word prefixing s.
+func (d *Dictionary) match(s string) int {
+	best, runes := 0, 0
+	for i := range s {
+		if runes == d.longest {
+			break
+		}
+		runes++
+		_, size := utf8.DecodeRuneInString(s[i:])
+		if d.words[s[:i+size]] {
+			best = i + size
+		}
+	}
+	return best
+}
+
+// unspacedScripts are written without spaces between words.
+var unspacedScripts = []*unicode.RangeTable{
+	unicode.Han, unicode.Hiragana, unicode.Katakana,
+	unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar,
+}
+
+func isUnspaced(r rune) bool {
+	// U+30FC KATAKANA-HIRAGANA PROLONGED SOUND MARK is in the Common script.
+	return r == '\u30fc' || unicode.IsOneOf(unspacedScripts, r)
+}
+
+// unspacedPrefix returns the byte length of the unspaced run at the start
+// of s, including combining marks attached to it.
+func unspacedPrefix(s string) int {
+	end := 0
+	for i, r := range s {
+		if !isUnspaced(r) && (end == 0 || !unicode.Is(unicode.Inherited, r)) {
+			break
+		}
+		end = i + utf8.RuneLen(r)
+	}
+	return end
+}
@@ -0,0 +1,240 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"errors"
+	"hash/fnv"
+	"io"
+	"os"
+	"slices"
+)
+
+// distinctKey is a 128-bit FNV-1a hash of a value. Only hashes are kept, so
+// memory does not grow with line length, and collisions are negligible
+// (about n²/2¹²⁹ for n distinct values).
+type distinctKey [16]byte
+
+// distinctRecord is a key and its occurrence count as stored in a run file.
+// Counts saturate at 2, which is all that is needed to tell duplicates.
+const distinctRecord = len(distinctKey{}) + 1
+
+// distinctFanIn is the most run files merged at once. Reaching it merges
+// the runs so far into one, so open files stay bounded however far the
+// input outgrows SpillAfter.
+const distinctFanIn = 64
+
+// distinct counts distinct values and values seen more than once. When
+// limit is positive, at most limit keys are held in memory; beyond that the
+// table is sorted and spilled to a temporary run file in dir, and the runs
+// are merged when the result is requested. Run files are closed between
+// spills and merges.
+type distinct struct {
+	limit int
+	dir   string
+
+	seen map[distinctKey]uint8
+	runs []string
+}
+
+func newDistinct(limit int, dir string) *distinct {
+	return &distinct{limit: limit, dir: dir, seen: make(map[distinctKey]uint8)}
+}
+
+func (d *distinct) add(value string) error {
+	var key distinctKey
+	h := fnv.New128a()
+	h.Write([]byte(value))
+	h.Sum(key[:0])
+
+	if n := d.seen[key]; n < 2 {
+		d.seen[key] = n + 1
+	}
+	if d.limit > 0 && len(d.seen) >= d.limit {
+		return d.spill()
+	}
+	return nil
+}
+
+// spill writes the in-memory table to a new run file in key order.
+func (d *distinct) spill() error {
+	if len(d.seen) == 0 {
+		return nil
+	}
+	keys := make([]distinctKey, 0, len(d.seen))
+	for key := range d.seen {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b distinctKey) int { return bytes.Compare(a[:], b[:]) })
+
+	err := d.writeRun(func(emit func(distinctKey, uint8) error) error {
+		for _, key := range keys {
+			if err := emit(key, d.seen[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	clear(d.seen)
+	if err != nil {
+		return err
+	}
+	if len(d.runs) >= distinctFanIn {
+		return d.compact()
+	}
+	return nil
+}
+
+// writeRun creates a run file from the records produced by fill, which
+// must be in key order.
+func (d *distinct) writeRun(fill func(emit func(distinctKey, uint8) error) error) error {
+	file, err := os.CreateTemp(d.dir, "wc-distinct-*")
+	if err != nil {
+		return err
+	}
+	d.runs = append(d.runs, file.Name())
+
+	w := bufio.NewWriter(file)
+	var record [distinctRecord]byte
+	err = fill(func(key distinctKey, count uint8) error {
+		copy(record[:], key[:])
+		record[len(key)] = count
+		_, err := w.Write(record[:])
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	return errors.Join(err, file.Close())
+}
+
+// compact merges every run so far into a single run.
+func (d *distinct) compact() error {
+	runs := d.runs
+	d.runs = nil
+	err := d.writeRun(func(emit func(distinctKey, uint8) error) error {
+		return mergeRuns(runs, emit)
+	})
+	for _, name := range runs {
+		err = errors.Join(err, os.Remove(name))
+	}
+	return err
+}
+
+// result returns the number of distinct values and the number of distinct
+// values that occurred more than once.
+func (d *distinct) result() (unique, duplicates int, err error) {
+	if len(d.runs) == 0 {
+		for _, n := range d.seen {
+			unique++
+			if n > 1 {
+				duplicates++
+			}
+		}
+		return unique, duplicates, nil
+	}
+
+	if err := d.spill(); err != nil {
+		return 0, 0, err
+	}
+	err = mergeRuns(d.runs, func(_ distinctKey, count uint8) error {
+		unique++
+		if count > 1 {
+			duplicates++
+		}
+		return nil
+	})
+	return unique, duplicates, err
+}
+
+// close removes any run files.
+func (d *distinct) close() error {
+	var errs []error
+	for _, name := range d.runs {
+		errs = append(errs, os.Remove(name))
+	}
+	d.runs = nil
+	return errors.Join(errs...)
+}
+
+// mergeRuns calls emit once per key across the named run files, in key
+// order, with the key's total count saturated at 2.
+func mergeRuns(names []string, emit func(distinctKey, uint8) error) (err error) {
+	runs := make(runHeap, 0, len(names))
+	defer func() {
+		for _, r := range runs {
+			err = errors.Join(err, r.file.Close())
+		}
+	}()
+	for _, name := range names {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		r := &run{file: file, reader: bufio.NewReader(file)}
+		runs = append(runs, r)
+		if ok, err := r.next(); err != nil {
+			return err
+		} else if !ok {
+			runs = runs[:len(runs)-1]
+			if err := file.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	heap.Init(&runs)
+
+	for len(runs) > 0 {
+		key, total := runs[0].key, uint8(0)
+		for len(runs) > 0 && runs[0].key == key {
+			total = min(total+runs[0].count, 2)
+			if ok, err := runs[0].next(); err != nil {
+				return err
+			} else if ok {
+				heap.Fix(&runs, 0)
+			} else if err := heap.Pop(&runs).(*run).file.Close(); err != nil {
+				return err
+			}
+		}
+		if err := emit(key, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run is a cursor over one sorted run file.
+type run struct {
+	file   *os.File
+	reader *bufio.Reader
+	key    distinctKey
+	count  uint8
+}
+
+// next advances to the following record, reporting false at the end.
+func (r *run) next() (bool, error) {
+	var record [distinctRecord]byte
+	if _, err := io.ReadFull(r.reader, record[:]); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	copy(r.key[:], record[:])
+	r.count = record[len(r.key)]
+	return true, nil
+}
+
+// runHeap orders runs by their current key for a k-way merge.
+type runHeap []*run
+
+func (h runHeap) Len() int           { return len(h) }
+func (h runHeap) Less(i, j int) bool { return bytes.Compare(h[i].key[:], h[j].key[:]) < 0 }
+func (h runHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x any)        { *h = append(*h, x.(*run)) }
+func (h *runHeap) Pop() any {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
@@ -0,0 +1,53 @@
+package command
+
+import "regexp"
+
+// lineFilter decides which lines reach the counters.
+type lineFilter struct {
+	ignore, only []*regexp.Regexp
+}
+
+func newLineFilter(f flags) (*lineFilter, error) {
+	if len(f.IgnoreLines) == 0 && len(f.OnlyLines) == 0 {
+		return nil, nil
+	}
+	ignore, err := compileAll(f.IgnoreLines)
+	if err != nil {
+		return nil, err
+	}
+	only, err := compileAll(f.OnlyLines)
+	if err != nil {
+		return nil, err
+	}
+	return &lineFilter{ignore: ignore, only: only}, nil
+}
+
+func compileAll(exprs []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(exprs))
+	for i, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = re
+	}
+	return res, nil
+}
+
+// keep reports whether line matches an OnlyLines pattern (if any are set)
+// and no IgnoreLines pattern.
+func (l *lineFilter) keep(line string) bool {
+	if len(l.only) > 0 && !matchesAny(l.only, line) {
+		return false
+	}
+	return !matchesAny(l.ignore, line)
+}
+
+func matchesAny(res []*regexp.Regexp, line string) bool {
+	for _, re := range res {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// frequencies tallies words for the TopWords table.
+type frequencies struct {
+	flags flags
+	fold  cases.Caser
+	stop  map[string]bool
+	seen  map[string]int
+}
+
+func newFrequencies(f flags) (*frequencies, error) {
+	t := &frequencies{
+		flags: f,
+		fold:  cases.Fold(),
+		stop:  make(map[string]bool),
+		seen:  make(map[string]int),
+	}
+	for _, word := range f.StopWords {
+		t.stop[t.key(word)] = true
+	}
+	if f.StopWordsFile != "" {
+		if err := t.loadStopWords(string(f.StopWordsFile)); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// loadStopWords reads whitespace-separated stop words from path.
+func (t *frequencies) loadStopWords(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		for _, word := range strings.Fields(scanner.Text()) {
+			t.stop[t.key(word)] = true
+		}
+	}
+	return scanner.Err()
+}
+
+// key returns the table key for word, folding case when requested.
+func (t *frequencies) key(word string) string {
+	if bool(t.flags.FoldCase) {
+		return t.fold.String(word)
+	}
+	return word
+}
+
+func (t *frequencies) add(words []string) {
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < int(t.flags.MinWordLength) {
+			continue
+		}
+		if key := t.key(word); !t.stop[key] {
+			t.seen[key]++
+		}
+	}
+}
+
+// write prints one "count word" line per entry, like uniq -c.
+func (t *frequencies) write(stdout io.Writer) error {
+	type entry struct {
+		word  string
+		count int
+	}
+	entries := make([]entry, 0, len(t.seen))
+	for word, count := range t.seen {
+		entries = append(entries, entry{word, count})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		var order int
+		switch t.flags.FrequencyOrder {
+		case MostFrequent:
+			order = cmp.Compare(b.count, a.count)
+		case LeastFrequent:
+			order = cmp.Compare(a.count, b.count)
+		}
+		return cmp.Or(order, strings.Compare(a.word, b.word))
+	})
+
+	if n := int(t.flags.TopWords); n > 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	for _, e := range entries {
+		if _, err := fmt.Fprintf(stdout, "%7d %s\n", e.count, e.word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,4 +2,8 @@ module github.com/yupsh/wc
 
 go 1.25
 
-require github.com/gloo-foo/framework v0.0.1
+require (
+	github.com/gloo-foo/framework v0.0.1
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.34.0
+)
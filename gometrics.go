@@ -0,0 +1,137 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+
+	gloo "github.com/gloo-foo/framework"
+)
+
+// goMetrics are the totals for one group of Go files. Packages are
+// distinct directory and package name pairs, so an external _test package
+// counts separately.
+type goMetrics struct {
+	files, lines, packages, types, funcs, methods, statements, tests int
+
+	seen map[string]bool
+}
+
+func (m *goMetrics) addPackage(dir, name string) {
+	if m.seen == nil {
+		m.seen = map[string]bool{}
+	}
+	if key := dir + "\x00" + name; !m.seen[key] {
+		m.seen[key] = true
+		m.packages++
+	}
+}
+
+// goMetricsExecutor parses each .go input and reports production code,
+// _test.go files and generated files separately.
+func (p command) goMetricsExecutor() gloo.CommandExecutor {
+	return func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer) error {
+		forced, err := p.Flags.language()
+		if err != nil {
+			return fmt.Errorf("go metrics: %w", err)
+		}
+		named, err := p.namedInputs(stdin)
+		if err != nil {
+			return fmt.Errorf("go metrics: %w", err)
+		}
+
+		var production, tests, generated, total goMetrics
+		fset := token.NewFileSet()
+		for _, input := range named {
+			if !strings.HasSuffix(input.name, ".go") && (forced == nil || forced.name != "Go") {
+				continue
+			}
+			src, err := io.ReadAll(input.reader)
+			if err != nil {
+				return err
+			}
+			file, err := parser.ParseFile(fset, input.name, src, parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+			dir, testFile := filepath.Dir(input.name), strings.HasSuffix(input.name, "_test.go")
+			switch {
+			case ast.IsGenerated(file):
+				generated.addFile(fset, file, dir, testFile)
+				continue
+			case testFile:
+				tests.addFile(fset, file, dir, testFile)
+			default:
+				production.addFile(fset, file, dir, testFile)
+			}
+			total.addFile(fset, file, dir, testFile)
+		}
+
+		row := func(m goMetrics, label string) error {
+			_, err := fmt.Fprintf(stdout, "%7d %7d %7d %7d %7d %7d %7d %7d %s\n",
+				m.files, m.lines, m.packages, m.types, m.funcs, m.methods, m.statements, m.tests, label)
+			return err
+		}
+		if err := row(production, "production"); err != nil {
+			return err
+		}
+		if err := row(tests, "test"); err != nil {
+			return err
+		}
+		if err := row(total, "total"); err != nil {
+			return err
+		}
+		if generated.files > 0 {
+			return row(generated, "generated")
+		}
+		return nil
+	}
+}
+
+// addFile counts the declarations and statements in file. Function
+// literals are not counted as functions, but their statements are.
+func (m *goMetrics) addFile(fset *token.FileSet, file *ast.File, dir string, testFile bool) {
+	m.files++
+	m.lines += fset.File(file.Pos()).LineCount()
+	m.addPackage(dir, file.Name.Name)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		switch {
+		case !ok:
+		case fn.Recv != nil:
+			m.methods++
+		default:
+			m.funcs++
+			if testFile && isTestFunc(fn.Name.Name) {
+				m.tests++
+			}
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.TypeSpec:
+			m.types++
+		case *ast.BlockStmt, *ast.EmptyStmt:
+		case ast.Stmt:
+			m.statements++
+		}
+		return true
+	})
+}
+
+// isTestFunc reports whether name is run by go test: Test, Benchmark,
+// Fuzz or Example, followed by nothing or a non-lower-case rune.
+func isTestFunc(name string) bool {
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return rest == "" || !('a' <= rest[0] && rest[0] <= 'z')
+		}
+	}
+	return false
+}
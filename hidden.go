@@ -0,0 +1,80 @@
+package command
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// byteOrderMark is U+FEFF, a BOM at the start of input and a zero width
+// no-break space anywhere else.
+const byteOrderMark = '\ufeff'
+
+// zeroWidthNames are the invisible characters counted by ZeroWidthChars.
+var zeroWidthNames = map[rune]string{
+	'\u180e': "MONGOLIAN VOWEL SEPARATOR",
+	'\u200b': "ZERO WIDTH SPACE",
+	'\u200c': "ZERO WIDTH NON-JOINER",
+	'\u200d': "ZERO WIDTH JOINER",
+	'\u2060': "WORD JOINER",
+	'\u2061': "FUNCTION APPLICATION",
+	'\u2062': "INVISIBLE TIMES",
+	'\u2063': "INVISIBLE SEPARATOR",
+	'\u2064': "INVISIBLE PLUS",
+}
+
+// bidiNames are the Bidi_Control characters: the embedding, override and
+// isolate controls that can reorder source code for display
+// (CVE-2021-42574, "Trojan Source"), and the invisible directional marks.
+var bidiNames = map[rune]string{
+	'\u061c': "ARABIC LETTER MARK",
+	'\u200e': "LEFT-TO-RIGHT MARK",
+	'\u200f': "RIGHT-TO-LEFT MARK",
+	'\u202a': "LEFT-TO-RIGHT EMBEDDING",
+	'\u202b': "RIGHT-TO-LEFT EMBEDDING",
+	'\u202c': "POP DIRECTIONAL FORMATTING",
+	'\u202d': "LEFT-TO-RIGHT OVERRIDE",
+	'\u202e': "RIGHT-TO-LEFT OVERRIDE",
+	'\u2066': "LEFT-TO-RIGHT ISOLATE",
+	'\u2067': "RIGHT-TO-LEFT ISOLATE",
+	'\u2068': "FIRST STRONG ISOLATE",
+	'\u2069': "POP DIRECTIONAL ISOLATE",
+}
+
+// hiddenChars counts invisible and bidirectional control characters.
+// Only the selected kinds are reported to Verbose.
+type hiddenChars struct {
+	zeroWidth, strayBOMs, bidi int
+
+	flags flags
+}
+
+// add scans line number as UTF-8 whatever the locale, since these
+// characters matter to editors and compilers rather than to wc. Columns
+// are 1-based byte offsets.
+func (h *hiddenChars) add(number int, line string, report *findings) {
+	for i, r := range line {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		switch {
+		case r == byteOrderMark:
+			if number == 1 && i == 0 {
+				continue
+			}
+			h.strayBOMs++
+			h.report(bool(h.flags.StrayBOMs), report, number, i+1, fmt.Sprintf("%U BYTE ORDER MARK not at start of input", r))
+		case zeroWidthNames[r] != "":
+			h.zeroWidth++
+			h.report(bool(h.flags.ZeroWidthChars), report, number, i+1, fmt.Sprintf("%U %s", r, zeroWidthNames[r]))
+		case bidiNames[r] != "":
+			h.bidi++
+			h.report(bool(h.flags.BidiControls), report, number, i+1, fmt.Sprintf("%U %s", r, bidiNames[r]))
+		}
+	}
+}
+
+func (h *hiddenChars) report(selected bool, report *findings, line, column int, message string) {
+	if selected {
+		report.add(line, column, message)
+	}
+}
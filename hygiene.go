@@ -0,0 +1,88 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// finding is one offending location reported in Verbose mode. Line
+// numbers count every record read, including those dropped by filters;
+// column is in bytes and omitted when zero.
+type finding struct {
+	line, column int
+	message      string
+}
+
+func (f finding) String() string {
+	if f.column == 0 {
+		return fmt.Sprintf("%d: %s", f.line, f.message)
+	}
+	return fmt.Sprintf("%d:%d: %s", f.line, f.column, f.message)
+}
+
+// findings collects locations in input order. A nil *findings discards
+// them, so counters report unconditionally.
+type findings []finding
+
+func (f *findings) add(line, column int, message string) {
+	if f != nil {
+		*f = append(*f, finding{line, column, message})
+	}
+}
+
+func (f findings) write(w io.Writer) error {
+	for _, finding := range f {
+		if _, err := fmt.Fprintln(w, finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hygiene counts whitespace problems for WhitespaceHygiene.
+type hygiene struct {
+	trailing, tabs, spaces, mixed int
+	missingNewline                bool
+}
+
+// add checks one line. Whitespace-only lines have trailing whitespace but
+// no indentation. One trailing CR is ignored, so CRLF input read with the
+// default LF terminator is checked like any other.
+func (h *hygiene) add(number int, line string, report *findings) {
+	line = strings.TrimSuffix(line, "\r")
+	if trimmed := strings.TrimRight(line, " \t"); len(trimmed) < len(line) {
+		h.trailing++
+		report.add(number, len(trimmed)+1, "trailing whitespace")
+		if trimmed == "" {
+			return
+		}
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	tabs, spaces := strings.Contains(indent, "\t"), strings.Contains(indent, " ")
+	switch {
+	case tabs && spaces:
+		h.mixed++
+		report.add(number, 0, "mixed indentation")
+	case tabs:
+		h.tabs++
+	case spaces:
+		h.spaces++
+	}
+}
+
+// end records whether the input stopped without a final terminator.
+func (h *hygiene) end(lines int, unterminated bool, report *findings) {
+	h.missingNewline = unterminated
+	if unterminated {
+		report.add(lines, 0, "no newline at end of input")
+	}
+}
+
+// flag formats a yes/no column as 1 or 0.
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"fmt"
+	"io"
+
+	gloo "github.com/gloo-foo/framework"
+)
+
+// namedInput is one input read on its own, for modes that report per file.
+type namedInput struct {
+	name   string // "-" for stdin and io.Reader parameters
+	reader io.Reader
+}
+
+// namedInputs pairs the opened readers with their file names. The
+// framework opens io.Reader parameters ahead of files and skips files it
+// cannot open, so a shortfall is an error rather than a misattribution.
+func (p command) namedInputs(stdin io.Reader) ([]namedInput, error) {
+	inputs := gloo.Inputs[gloo.File, flags](p)
+	readers := inputs.Readers()
+	unnamed := len(readers) - len(inputs.Positional)
+	if unnamed < 0 {
+		return nil, fmt.Errorf("%d of %d inputs could not be opened", -unnamed, len(inputs.Positional))
+	}
+	if len(readers) == 0 {
+		return []namedInput{{name: "-", reader: stdin}}, nil
+	}
+	named := make([]namedInput, len(readers))
+	for i, r := range readers {
+		named[i] = namedInput{name: "-", reader: r}
+		if i >= unnamed {
+			named[i].name = string(inputs.Positional[i-unnamed])
+		}
+	}
+	return named, nil
+}
@@ -0,0 +1,161 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// language describes the comment and string syntax used to classify lines.
+type language struct {
+	name          string
+	extensions    []string // including the dot, lower case
+	filenames     []string // exact base names, such as "Makefile"
+	interpreters  []string // shebang interpreters
+	lineComments  []string
+	blockComments []delimiters
+	nested        bool // block comments nest, as in Rust and Haskell
+	docstrings    bool // a statement that is only a multi-line string is a comment, as in Python
+	strings       []stringSyntax
+}
+
+type delimiters struct{ open, close string }
+
+// stringSyntax is a string literal, so comment markers inside it are code.
+type stringSyntax struct {
+	delimiters
+	multiline bool // may span lines, like Go raw strings or Python """
+	escapes   bool // a backslash escapes the next byte
+}
+
+var (
+	doubleQuoted = stringSyntax{delimiters{`"`, `"`}, false, true}
+	singleQuoted = stringSyntax{delimiters{`'`, `'`}, false, true}
+	backquoted   = stringSyntax{delimiters{"`", "`"}, true, false}
+	tripleDouble = stringSyntax{delimiters{`"""`, `"""`}, true, true}
+	tripleSingle = stringSyntax{delimiters{`'''`, `'''`}, true, true}
+
+	cBlock  = delimiters{"/*", "*/"}
+	cFamily = []stringSyntax{doubleQuoted, singleQuoted}
+)
+
+// languages are checked in order; the first match wins.
+var languages = []language{
+	{name: "Go", extensions: []string{".go"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, strings: []stringSyntax{doubleQuoted, singleQuoted, backquoted}},
+	{name: "C", extensions: []string{".c", ".h"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, strings: cFamily},
+	{name: "C++", extensions: []string{".cc", ".cpp", ".cxx", ".hh", ".hpp", ".hxx"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, strings: cFamily},
+	{name: "C#", extensions: []string{".cs"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, strings: cFamily},
+	{name: "Java", extensions: []string{".java"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, strings: []stringSyntax{tripleDouble, doubleQuoted, singleQuoted}},
+	{name: "Kotlin", extensions: []string{".kt", ".kts"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, nested: true, strings: []stringSyntax{tripleDouble, doubleQuoted, singleQuoted}},
+	{name: "Swift", extensions: []string{".swift"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, nested: true, strings: []stringSyntax{tripleDouble, doubleQuoted}},
+	{name: "Rust", extensions: []string{".rs"}, lineComments: []string{"//"},
+		blockComments: []delimiters{cBlock}, nested: true, strings: []stringSyntax{doubleQuoted}},
+	{name: "JavaScript", extensions: []string{".js", ".mjs", ".cjs", ".jsx"}, interpreters: []string{"node"},
+		lineComments: []string{"//"}, blockComments: []delimiters{cBlock},
+		strings: []stringSyntax{doubleQuoted, singleQuoted, backquoted}},
+	{name: "TypeScript", extensions: []string{".ts", ".tsx"}, interpreters: []string{"deno", "ts-node"},
+		lineComments: []string{"//"}, blockComments: []delimiters{cBlock},
+		strings: []stringSyntax{doubleQuoted, singleQuoted, backquoted}},
+	{name: "CSS", extensions: []string{".css"}, blockComments: []delimiters{cBlock}, strings: cFamily},
+	{name: "Python", extensions: []string{".py"}, interpreters: []string{"python", "python2", "python3"},
+		lineComments: []string{"#"}, docstrings: true, strings: []stringSyntax{tripleDouble, tripleSingle, doubleQuoted, singleQuoted}},
+	{name: "Ruby", extensions: []string{".rb"}, filenames: []string{"Rakefile", "Gemfile"}, interpreters: []string{"ruby"},
+		lineComments: []string{"#"}, strings: cFamily},
+	{name: "Perl", extensions: []string{".pl", ".pm"}, interpreters: []string{"perl"},
+		lineComments: []string{"#"}, strings: cFamily},
+	{name: "Shell", extensions: []string{".sh", ".bash", ".zsh"}, interpreters: []string{"sh", "bash", "zsh", "dash", "ksh"},
+		lineComments: []string{"#"}, strings: cFamily},
+	{name: "Makefile", extensions: []string{".mk"}, filenames: []string{"Makefile", "GNUmakefile", "makefile"},
+		lineComments: []string{"#"}},
+	{name: "Dockerfile", filenames: []string{"Dockerfile"}, lineComments: []string{"#"}},
+	{name: "YAML", extensions: []string{".yaml", ".yml"}, lineComments: []string{"#"}, strings: cFamily},
+	{name: "TOML", extensions: []string{".toml"}, lineComments: []string{"#"},
+		strings: []stringSyntax{tripleDouble, tripleSingle, doubleQuoted, singleQuoted}},
+	{name: "SQL", extensions: []string{".sql"}, lineComments: []string{"--"},
+		blockComments: []delimiters{cBlock}, strings: []stringSyntax{singleQuoted, doubleQuoted}},
+	{name: "Haskell", extensions: []string{".hs"}, interpreters: []string{"runhaskell"}, lineComments: []string{"--"},
+		blockComments: []delimiters{{"{-", "-}"}}, nested: true, strings: []stringSyntax{doubleQuoted}},
+	{name: "Lua", extensions: []string{".lua"}, interpreters: []string{"lua"}, lineComments: []string{"--"},
+		blockComments: []delimiters{{"--[[", "]]"}}, strings: cFamily},
+	{name: "HTML", extensions: []string{".html", ".htm"}, blockComments: []delimiters{{"<!--", "-->"}}},
+	{name: "XML", extensions: []string{".xml", ".svg"}, blockComments: []delimiters{{"<!--", "-->"}}},
+	{name: "PHP", extensions: []string{".php"}, interpreters: []string{"php"}, lineComments: []string{"//", "#"},
+		blockComments: []delimiters{cBlock}, strings: cFamily},
+}
+
+// languageByName finds a language by case-insensitive name.
+func languageByName(name string) *language {
+	for i := range languages {
+		if strings.EqualFold(languages[i].name, name) {
+			return &languages[i]
+		}
+	}
+	return nil
+}
+
+// language returns the language forced by Language, or nil to detect it.
+func (f flags) language() (*language, error) {
+	if f.Language == "" {
+		return nil, nil
+	}
+	if lang := languageByName(string(f.Language)); lang != nil {
+		return lang, nil
+	}
+	return nil, fmt.Errorf("unknown language %q", string(f.Language))
+}
+
+// languageForPath detects a language from a file's name or extension.
+func languageForPath(path string) *language {
+	base := filepath.Base(path)
+	ext := strings.ToLower(filepath.Ext(base))
+	for i := range languages {
+		for _, name := range languages[i].filenames {
+			if base == name {
+				return &languages[i]
+			}
+		}
+		for _, e := range languages[i].extensions {
+			if ext == e {
+				return &languages[i]
+			}
+		}
+	}
+	return nil
+}
+
+// languageForShebang detects a language from a "#!" first line, looking
+// through "env" to the interpreter it runs.
+func languageForShebang(line string) *language {
+	command, found := strings.CutPrefix(line, "#!")
+	if !found {
+		return nil
+	}
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+	for i := range languages {
+		for _, name := range languages[i].interpreters {
+			if interpreter == name || strings.TrimRight(interpreter, "0123456789.") == name {
+				return &languages[i]
+			}
+		}
+	}
+	return nil
+}
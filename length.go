@@ -0,0 +1,150 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// tabWidth is the tab stop interval for LengthWidth.
+const tabWidth = 8
+
+// measure returns the length of line in the unit. Runes and display
+// columns are bytes in the C locale.
+func (u LengthUnitFlag) measure(line string, locale LocaleFlag) int {
+	switch u {
+	case LengthRunes:
+		return locale.countChars(line)
+	case LengthWidth:
+		return displayWidth(line, locale)
+	}
+	return len(line)
+}
+
+// displayWidth returns the terminal columns line occupies, starting at
+// column zero.
+func displayWidth(line string, locale LocaleFlag) int {
+	width := 0
+	for i, segment := range strings.Split(line, "\t") {
+		if i > 0 {
+			width += tabWidth - width%tabWidth
+		}
+		if locale == LocaleC {
+			width += len(segment)
+		} else {
+			width += uniseg.StringWidth(segment)
+		}
+	}
+	return width
+}
+
+// exactLengths is how many distinct lengths lengthStats tracks exactly
+// before folding them into logarithmic buckets.
+const exactLengths = 1 << 12
+
+// lengthAccuracy is the relative error of quantiles once folded.
+const lengthAccuracy = 0.01
+
+// lengthStats summarizes line lengths in bounded memory. Lengths are kept
+// exactly while few are distinct, which covers most text; after that they
+// fold into buckets whose bounds grow by a constant ratio, as in DDSketch,
+// so any quantile is within lengthAccuracy of the true value.
+type lengthStats struct {
+	lines, sum, min int
+	folded          bool
+	counts          map[int]int // length, or bucket index once folded
+	histogram       map[int]int // LengthHistogram bucket to count
+	gamma           float64
+}
+
+func newLengthStats() *lengthStats {
+	return &lengthStats{
+		counts:    map[int]int{},
+		histogram: map[int]int{},
+		gamma:     (1 + lengthAccuracy) / (1 - lengthAccuracy),
+	}
+}
+
+func (s *lengthStats) add(length, bucketWidth int) {
+	if s.lines == 0 || length < s.min {
+		s.min = length
+	}
+	s.lines++
+	s.sum += length
+	if bucketWidth > 0 {
+		s.histogram[length/bucketWidth]++
+	}
+	if s.folded {
+		s.counts[s.bucket(length)]++
+		return
+	}
+	s.counts[length]++
+	if len(s.counts) > exactLengths {
+		exact := s.counts
+		s.counts, s.folded = map[int]int{}, true
+		for length, n := range exact {
+			s.counts[s.bucket(length)] += n
+		}
+	}
+}
+
+// bucket returns the index of the bucket holding length. Zero has a
+// bucket of its own.
+func (s *lengthStats) bucket(length int) int {
+	if length == 0 {
+		return -1
+	}
+	return int(math.Ceil(math.Log(float64(length)) / math.Log(s.gamma)))
+}
+
+// value returns the length a bucket represents, within lengthAccuracy of
+// every length in it.
+func (s *lengthStats) value(bucket int) int {
+	if bucket < 0 {
+		return 0
+	}
+	return int(math.Round(2 * math.Pow(s.gamma, float64(bucket)) / (s.gamma + 1)))
+}
+
+func (s *lengthStats) mean() float64 {
+	if s.lines == 0 {
+		return 0
+	}
+	return float64(s.sum) / float64(s.lines)
+}
+
+// percentile returns the nearest-rank pth percentile, 0 < p <= 100.
+func (s *lengthStats) percentile(p float64) int {
+	if s.lines == 0 {
+		return 0
+	}
+	keys := slices.Sorted(maps.Keys(s.counts))
+	rank := int(math.Ceil(p / 100 * float64(s.lines)))
+	seen := 0
+	for _, key := range keys {
+		if seen += s.counts[key]; seen >= rank {
+			if s.folded {
+				return s.value(key)
+			}
+			return key
+		}
+	}
+	return keys[len(keys)-1]
+}
+
+// writeHistogram prints one "count low-high" line per non-empty bucket,
+// shortest first.
+func (s *lengthStats) writeHistogram(w io.Writer, width int) error {
+	for _, bucket := range slices.Sorted(maps.Keys(s.histogram)) {
+		low := bucket * width
+		if _, err := fmt.Fprintf(w, "%7d %d-%d\n", s.histogram[bucket], low, low+width-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// localeVariables are consulted in POSIX precedence order.
+var localeVariables = []string{"LC_ALL", "LC_CTYPE", "LANG"}
+
+// locale returns the effective locale for counting.
+func (f flags) locale() LocaleFlag {
+	if !bool(f.EnvLocale) {
+		return f.Locale
+	}
+	return localeFromEnv(os.Getenv)
+}
+
+// localeFromEnv picks the first non-empty locale variable. As in libc, an
+// unset environment means the C locale.
+func localeFromEnv(getenv func(string) string) LocaleFlag {
+	for _, name := range localeVariables {
+		if value := getenv(name); value != "" {
+			return parseLocale(value)
+		}
+	}
+	return LocaleC
+}
+
+// parseLocale maps a locale name such as "en_US.UTF-8" or "C" to a mode.
+// Only UTF-8 codesets are multibyte; everything else counts bytes.
+func parseLocale(name string) LocaleFlag {
+	_, codeset, found := strings.Cut(name, ".")
+	if !found {
+		return LocaleC
+	}
+	codeset, _, _ = strings.Cut(codeset, "@")
+	switch strings.ToLower(strings.ReplaceAll(codeset, "-", "")) {
+	case "utf8":
+		return LocaleUTF8
+	}
+	return LocaleC
+}
+
+// countChars counts characters in line under the locale.
+func (l LocaleFlag) countChars(line string) int {
+	if l == LocaleC {
+		return len(line)
+	}
+	return utf8.RuneCountInString(line)
+}
+
+// countGraphemes counts user-perceived characters (UAX #29 extended grapheme
+// clusters) in line. The C locale has no multibyte characters, so every byte
+// is its own cluster.
+func (l LocaleFlag) countGraphemes(line string) int {
+	if l == LocaleC {
+		return len(line)
+	}
+	return uniseg.GraphemeClusterCount(line)
+}
+
+// isBlank reports whether line holds only whitespace: ASCII whitespace in
+// the C locale, any Unicode White_Space character otherwise.
+func (l LocaleFlag) isBlank(line string) bool {
+	if l == LocaleC {
+		return strings.TrimFunc(line, isASCIISpace) == ""
+	}
+	return strings.TrimFunc(line, unicode.IsSpace) == ""
+}
+
+// isASCIISpace reports whether r is whitespace in the C locale.
+func isASCIISpace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package command
+
+import "golang.org/x/text/unicode/norm"
+
+// normalize returns line in the selected Unicode normalization form.
+func (n NormalizationFlag) normalize(line string) string {
+	switch n {
+	case NFC:
+		return norm.NFC.String(line)
+	case NFD:
+		return norm.NFD.String(line)
+	case NFKC:
+		return norm.NFKC.String(line)
+	case NFKD:
+		return norm.NFKD.String(line)
+	}
+	return line
+}
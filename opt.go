@@ -7,6 +7,57 @@ const (
 	NoLines LinesFlag = false
 )
 
+// EmptyLinesFlag adds the number of lines with no bytes at all.
+type EmptyLinesFlag bool
+
+const (
+	EmptyLines   EmptyLinesFlag = true
+	NoEmptyLines EmptyLinesFlag = false
+)
+
+// WhitespaceLinesFlag adds the number of lines holding only whitespace,
+// which are blank but not empty.
+type WhitespaceLinesFlag bool
+
+const (
+	WhitespaceLines   WhitespaceLinesFlag = true
+	NoWhitespaceLines WhitespaceLinesFlag = false
+)
+
+// NonBlankLinesFlag adds the number of lines with anything but whitespace.
+type NonBlankLinesFlag bool
+
+const (
+	NonBlankLines   NonBlankLinesFlag = true
+	NoNonBlankLines NonBlankLinesFlag = false
+)
+
+// UniqueLinesFlag adds the number of distinct lines, like sort -u | wc -l.
+type UniqueLinesFlag bool
+
+const (
+	UniqueLines   UniqueLinesFlag = true
+	NoUniqueLines UniqueLinesFlag = false
+)
+
+// DuplicateLinesFlag adds the number of distinct lines that occur more than
+// once, like sort | uniq -d | wc -l.
+type DuplicateLinesFlag bool
+
+const (
+	DuplicateLines   DuplicateLinesFlag = true
+	NoDuplicateLines DuplicateLinesFlag = false
+)
+
+// ApproxUniqueLinesFlag adds a HyperLogLog estimate of distinct lines and
+// its standard error, in constant memory.
+type ApproxUniqueLinesFlag bool
+
+const (
+	ApproxUniqueLines   ApproxUniqueLinesFlag = true
+	NoApproxUniqueLines ApproxUniqueLinesFlag = false
+)
+
 type WordsFlag bool
 
 const (
@@ -14,6 +65,94 @@ const (
 	NoWords WordsFlag = false
 )
 
+// UniqueWordsFlag adds the number of distinct words, split like Words.
+type UniqueWordsFlag bool
+
+const (
+	UniqueWords   UniqueWordsFlag = true
+	NoUniqueWords UniqueWordsFlag = false
+)
+
+// ApproxUniqueWordsFlag adds a HyperLogLog estimate of distinct words and
+// its standard error.
+type ApproxUniqueWordsFlag bool
+
+const (
+	ApproxUniqueWords   ApproxUniqueWordsFlag = true
+	NoApproxUniqueWords ApproxUniqueWordsFlag = false
+)
+
+// MeanWordLengthFlag adds the mean word length in characters, to one
+// decimal place, for words split like Words.
+type MeanWordLengthFlag bool
+
+const (
+	MeanWordLength   MeanWordLengthFlag = true
+	NoMeanWordLength MeanWordLengthFlag = false
+)
+
+// LongestWordFlag adds three columns: the length in characters of the
+// first longest word, its line number, and the word quoted.
+type LongestWordFlag bool
+
+const (
+	LongestWord   LongestWordFlag = true
+	NoLongestWord LongestWordFlag = false
+)
+
+// WordLengthHistogramFlag prints a histogram of word lengths after the
+// counts and any LengthHistogram, in buckets of the given width.
+type WordLengthHistogramFlag int
+
+// WordLengthHistogram returns a WordLengthHistogramFlag for buckets width
+// wide.
+func WordLengthHistogram(width int) WordLengthHistogramFlag { return WordLengthHistogramFlag(width) }
+
+type SentencesFlag bool
+
+const (
+	Sentences   SentencesFlag = true
+	NoSentences SentencesFlag = false
+)
+
+type ParagraphsFlag bool
+
+const (
+	Paragraphs   ParagraphsFlag = true
+	NoParagraphs ParagraphsFlag = false
+)
+
+type SyllablesFlag bool
+
+const (
+	Syllables   SyllablesFlag = true
+	NoSyllables SyllablesFlag = false
+)
+
+// ReadingEaseFlag adds the Flesch reading ease score.
+type ReadingEaseFlag bool
+
+const (
+	ReadingEase   ReadingEaseFlag = true
+	NoReadingEase ReadingEaseFlag = false
+)
+
+// GradeLevelFlag adds the Flesch-Kincaid grade level.
+type GradeLevelFlag bool
+
+const (
+	GradeLevel   GradeLevelFlag = true
+	NoGradeLevel GradeLevelFlag = false
+)
+
+// ReadingTimeFlag adds the estimated reading time in minutes.
+type ReadingTimeFlag bool
+
+const (
+	ReadingTime   ReadingTimeFlag = true
+	NoReadingTime ReadingTimeFlag = false
+)
+
 type CharsFlag bool
 
 const (
@@ -21,6 +160,71 @@ const (
 	NoChars CharsFlag = false
 )
 
+// CharClassesFlag adds nine columns splitting Chars by Unicode general
+// category: letters, marks, numbers, punctuation, symbols, separators,
+// control, format, and everything else.
+type CharClassesFlag bool
+
+const (
+	CharClasses   CharClassesFlag = true
+	NoCharClasses CharClassesFlag = false
+)
+
+// ScriptsFlag prints the characters of each Unicode script, such as
+// Latin, Cyrillic or Han, with their share of all characters, after the
+// counts. Digits, punctuation and spaces are Common.
+type ScriptsFlag bool
+
+const (
+	Scripts   ScriptsFlag = true
+	NoScripts ScriptsFlag = false
+)
+
+// BlocksFlag prints the characters of each Unicode block, such as Basic
+// Latin or CJK Unified Ideographs, with their share of all characters,
+// after the counts and any Scripts table.
+type BlocksFlag bool
+
+const (
+	Blocks   BlocksFlag = true
+	NoBlocks BlocksFlag = false
+)
+
+// ZeroWidthCharsFlag adds the number of zero-width and invisible
+// characters such as U+200B ZERO WIDTH SPACE. With Verbose, each is listed.
+type ZeroWidthCharsFlag bool
+
+const (
+	ZeroWidthChars   ZeroWidthCharsFlag = true
+	NoZeroWidthChars ZeroWidthCharsFlag = false
+)
+
+// StrayBOMsFlag adds the number of U+FEFF characters anywhere but the
+// very start of input, as left behind by concatenating files.
+type StrayBOMsFlag bool
+
+const (
+	StrayBOMs   StrayBOMsFlag = true
+	NoStrayBOMs StrayBOMsFlag = false
+)
+
+// BidiControlsFlag adds the number of Bidi_Control characters: the
+// embedding, override and isolate controls behind "Trojan Source" attacks,
+// and the directional marks LRM (U+200E), RLM (U+200F) and ALM (U+061C).
+type BidiControlsFlag bool
+
+const (
+	BidiControls   BidiControlsFlag = true
+	NoBidiControls BidiControlsFlag = false
+)
+
+type GraphemesFlag bool
+
+const (
+	Graphemes   GraphemesFlag = true
+	NoGraphemes GraphemesFlag = false
+)
+
 type BytesFlag bool
 
 const (
@@ -35,16 +239,441 @@ const (
 	NoMaxLength MaxLengthFlag = false
 )
 
+// MaxLengthLineFlag adds the line number of the first longest line.
+type MaxLengthLineFlag bool
+
+const (
+	MaxLengthLine   MaxLengthLineFlag = true
+	NoMaxLengthLine MaxLengthLineFlag = false
+)
+
+// LineLimitFlag adds a column counting lines longer than the limit. With
+// Verbose, each such line is listed with its length.
+type LineLimitFlag int
+
+// LineLimit returns a LineLimitFlag for n.
+func LineLimit(n int) LineLimitFlag { return LineLimitFlag(n) }
+
+// MinLengthFlag adds the length of the shortest line.
+type MinLengthFlag bool
+
+const (
+	MinLength   MinLengthFlag = true
+	NoMinLength MinLengthFlag = false
+)
+
+// MeanLengthFlag adds the mean line length to one decimal place.
+type MeanLengthFlag bool
+
+const (
+	MeanLength   MeanLengthFlag = true
+	NoMeanLength MeanLengthFlag = false
+)
+
+// LengthPercentilesFlag adds a column per percentile of line length, such
+// as 50 for the median. Past a few thousand distinct lengths the values
+// are estimates within 1%.
+type LengthPercentilesFlag []float64
+
+// LengthPercentiles returns a LengthPercentilesFlag for ps, each in (0, 100].
+func LengthPercentiles(ps ...float64) LengthPercentilesFlag { return LengthPercentilesFlag(ps) }
+
+// LengthHistogramFlag prints a histogram of line lengths after the counts,
+// in buckets of the given width.
+type LengthHistogramFlag int
+
+// LengthHistogram returns a LengthHistogramFlag for buckets width wide.
+func LengthHistogram(width int) LengthHistogramFlag { return LengthHistogramFlag(width) }
+
+// LengthUnitFlag selects how MaxLength, LineLimit and the length
+// statistics measure a line.
+// LengthWidth is the display width: East Asian wide characters take two
+// columns, combining marks none, and tabs advance to the next multiple of
+// eight, like wc -L.
+type LengthUnitFlag int
+
+const (
+	LengthBytes LengthUnitFlag = iota
+	LengthRunes
+	LengthWidth
+)
+
+// LocaleFlag selects how characters and word separators are interpreted.
+// LocaleUTF8 counts runes and splits words on any Unicode whitespace;
+// LocaleC counts bytes as characters and splits words on ASCII whitespace
+// only, matching coreutils under LC_ALL=C.
+type LocaleFlag int
+
+const (
+	LocaleUTF8 LocaleFlag = iota
+	LocaleC
+)
+
+// EnvLocaleFlag resolves the locale from LC_ALL, LC_CTYPE and LANG (in that
+// order) instead of the Locale option.
+type EnvLocaleFlag bool
+
+const (
+	EnvLocale   EnvLocaleFlag = true
+	NoEnvLocale EnvLocaleFlag = false
+)
+
+// TerminatorFlag is the byte sequence that ends a record. The zero value
+// means LF. Any other string may be used via Terminator.
+type TerminatorFlag string
+
+const (
+	LF   TerminatorFlag = "\n"
+	CRLF TerminatorFlag = "\r\n"
+	CR   TerminatorFlag = "\r"
+	NUL  TerminatorFlag = "\x00"
+)
+
+// Terminator returns a TerminatorFlag for an arbitrary delimiter.
+func Terminator(sep string) TerminatorFlag { return TerminatorFlag(sep) }
+
+// UnicodeLineBreaksFlag ends records at every UAX #14 mandatory break (LF,
+// CR, CR LF, NEL, VT, FF, U+2028 and U+2029) instead of the Terminator.
+type UnicodeLineBreaksFlag bool
+
+const (
+	UnicodeLineBreaks   UnicodeLineBreaksFlag = true
+	NoUnicodeLineBreaks UnicodeLineBreaksFlag = false
+)
+
+// NormalizationFlag rewrites each record into a Unicode normalization form
+// before anything is counted, so composed and decomposed text agree.
+type NormalizationFlag int
+
+const (
+	NoNormalization NormalizationFlag = iota
+	NFC
+	NFD
+	NFKC
+	NFKD
+)
+
+// SentenceRuleFlag selects how Sentences finds sentence ends.
+// UnicodeSentences follows UAX #29 sentence boundaries;
+// PunctuationSentences ends at terminal punctuation followed by whitespace,
+// skipping Abbreviations.
+type SentenceRuleFlag int
+
+const (
+	UnicodeSentences SentenceRuleFlag = iota
+	PunctuationSentences
+)
+
+// AbbreviationsFlag replaces the words that do not end a sentence under
+// PunctuationSentences, such as "Dr." or "e.g.".
+type AbbreviationsFlag []string
+
+// Abbreviations returns an AbbreviationsFlag for words.
+func Abbreviations(words ...string) AbbreviationsFlag { return AbbreviationsFlag(words) }
+
+// SentenceLimitFlag adds a column counting paragraphs with more sentences
+// than the limit.
+type SentenceLimitFlag int
+
+// SentenceLimit returns a SentenceLimitFlag for n sentences per paragraph.
+func SentenceLimit(n int) SentenceLimitFlag { return SentenceLimitFlag(n) }
+
+// WordsPerMinuteFlag sets the reading speed for ReadingTime (default 238).
+type WordsPerMinuteFlag int
+
+// WordsPerMinute returns a WordsPerMinuteFlag for n.
+func WordsPerMinute(n int) WordsPerMinuteFlag { return WordsPerMinuteFlag(n) }
+
+// TopWordsFlag replaces the totals with a table of the n most frequent
+// words, split like Words. A negative n lists every word.
+type TopWordsFlag int
+
+// TopWords returns a TopWordsFlag for n.
+func TopWords(n int) TopWordsFlag { return TopWordsFlag(n) }
+
+// FoldCaseFlag makes TopWords treat words differing only in case as one.
+type FoldCaseFlag bool
+
+const (
+	FoldCase   FoldCaseFlag = true
+	NoFoldCase FoldCaseFlag = false
+)
+
+// MinWordLengthFlag leaves words shorter than n runes out of TopWords.
+type MinWordLengthFlag int
+
+// MinWordLength returns a MinWordLengthFlag for n.
+func MinWordLength(n int) MinWordLengthFlag { return MinWordLengthFlag(n) }
+
+// StopWordsFlag leaves the given words out of TopWords.
+type StopWordsFlag []string
+
+// StopWords returns a StopWordsFlag for words.
+func StopWords(words ...string) StopWordsFlag { return StopWordsFlag(words) }
+
+// StopWordsFileFlag names a file of whitespace-separated stop words.
+type StopWordsFileFlag string
+
+// StopWordsFile returns a StopWordsFileFlag for path.
+func StopWordsFile(path string) StopWordsFileFlag { return StopWordsFileFlag(path) }
+
+// FrequencyOrderFlag sorts the TopWords table. Ties are broken
+// alphabetically.
+type FrequencyOrderFlag int
+
+const (
+	MostFrequent FrequencyOrderFlag = iota
+	LeastFrequent
+	Alphabetical
+)
+
+// SpillAfterFlag bounds the distinct counters to n hashes in memory each;
+// beyond that, sorted runs are written to temporary files and merged at the
+// end. Zero keeps everything in memory.
+type SpillAfterFlag int
+
+// SpillAfter returns a SpillAfterFlag for n hashes.
+func SpillAfter(n int) SpillAfterFlag { return SpillAfterFlag(n) }
+
+// SpillDirFlag is the directory for spilled runs (default os.TempDir()).
+type SpillDirFlag string
+
+// SpillDir returns a SpillDirFlag for dir.
+func SpillDir(dir string) SpillDirFlag { return SpillDirFlag(dir) }
+
+// PrecisionFlag sets the precision of the sketches Wc creates for
+// ApproxUniqueLines and ApproxUniqueWords (default 14).
+type PrecisionFlag int
+
+// Precision returns a PrecisionFlag for p index bits.
+func Precision(p int) PrecisionFlag { return PrecisionFlag(p) }
+
+// LineSketchFlag makes Wc add lines to a caller-owned Sketch, which keeps
+// its contents and precision. Sketches from per-file runs can then be
+// merged and passed back in to report the total.
+type LineSketchFlag struct{ *Sketch }
+
+// LineSketch returns a LineSketchFlag for s.
+func LineSketch(s *Sketch) LineSketchFlag { return LineSketchFlag{s} }
+
+// WordSketchFlag is LineSketchFlag for words.
+type WordSketchFlag struct{ *Sketch }
+
+// WordSketch returns a WordSketchFlag for s.
+func WordSketch(s *Sketch) WordSketchFlag { return WordSketchFlag{s} }
+
+// PatternFlag adds two columns per pattern: the lines matching it and its
+// total number of non-overlapping matches. Patterns from several options
+// accumulate, in order.
+type PatternFlag []patternSpec
+
+// Pattern returns a PatternFlag for regular expressions in RE2 syntax.
+func Pattern(exprs ...string) PatternFlag {
+	flag := make(PatternFlag, len(exprs))
+	for i, expr := range exprs {
+		flag[i] = patternSpec{expr: expr}
+	}
+	return flag
+}
+
+// FixedStrings returns a PatternFlag for literal strings. Large sets are
+// matched together with an Aho-Corasick automaton.
+func FixedStrings(strs ...string) PatternFlag {
+	flag := make(PatternFlag, len(strs))
+	for i, s := range strs {
+		flag[i] = patternSpec{expr: s, fixed: true}
+	}
+	return flag
+}
+
+// IgnoreLinesFlag drops lines matching any of its regular expressions
+// before anything is counted. Expressions from several options accumulate.
+type IgnoreLinesFlag []string
+
+// IgnoreLines returns an IgnoreLinesFlag for exprs.
+func IgnoreLines(exprs ...string) IgnoreLinesFlag { return IgnoreLinesFlag(exprs) }
+
+// OnlyLinesFlag counts only lines matching at least one of its regular
+// expressions. IgnoreLines still applies to the lines it keeps.
+type OnlyLinesFlag []string
+
+// OnlyLines returns an OnlyLinesFlag for exprs.
+func OnlyLines(exprs ...string) OnlyLinesFlag { return OnlyLinesFlag(exprs) }
+
+// SLOCFlag replaces the totals with a count of blank, comment and code
+// lines for each input, each language and overall, like cloc.
+type SLOCFlag bool
+
+const (
+	SLOC   SLOCFlag = true
+	NoSLOC SLOCFlag = false
+)
+
+// LanguageFlag makes SLOC read every input as the named language instead
+// of detecting it from the file name or a "#!" line.
+type LanguageFlag string
+
+// Language returns a LanguageFlag for name, such as "Go" or "Python".
+func Language(name string) LanguageFlag { return LanguageFlag(name) }
+
+// GoMetricsFlag replaces the totals with Go declaration and statement
+// counts for .go inputs, in production, test, total and generated rows.
+type GoMetricsFlag bool
+
+const (
+	GoMetrics   GoMetricsFlag = true
+	NoGoMetrics GoMetricsFlag = false
+)
+
+// WhitespaceHygieneFlag adds five columns: lines with trailing whitespace,
+// lines indented with tabs, with spaces, with both, and 1 if the input
+// does not end with a terminator.
+type WhitespaceHygieneFlag bool
+
+const (
+	WhitespaceHygiene   WhitespaceHygieneFlag = true
+	NoWhitespaceHygiene WhitespaceHygieneFlag = false
+)
+
+// VerboseFlag prints the location of each problem found by checks such as
+// WhitespaceHygiene, one per line, before the counts.
+type VerboseFlag bool
+
+const (
+	Verbose   VerboseFlag = true
+	NoVerbose VerboseFlag = false
+)
+
 type flags struct {
-	Lines     LinesFlag
-	Words     WordsFlag
-	Chars     CharsFlag
-	Bytes     BytesFlag
-	MaxLength MaxLengthFlag
+	Lines               LinesFlag
+	EmptyLines          EmptyLinesFlag
+	WhitespaceLines     WhitespaceLinesFlag
+	NonBlankLines       NonBlankLinesFlag
+	UniqueLines         UniqueLinesFlag
+	DuplicateLines      DuplicateLinesFlag
+	ApproxUniqueLines   ApproxUniqueLinesFlag
+	Words               WordsFlag
+	UniqueWords         UniqueWordsFlag
+	ApproxUniqueWords   ApproxUniqueWordsFlag
+	MeanWordLength      MeanWordLengthFlag
+	LongestWord         LongestWordFlag
+	WordLengthHistogram WordLengthHistogramFlag
+	Sentences           SentencesFlag
+	Paragraphs          ParagraphsFlag
+	Syllables           SyllablesFlag
+	ReadingEase         ReadingEaseFlag
+	GradeLevel          GradeLevelFlag
+	ReadingTime         ReadingTimeFlag
+	Chars               CharsFlag
+	CharClasses         CharClassesFlag
+	Scripts             ScriptsFlag
+	Blocks              BlocksFlag
+	ZeroWidthChars      ZeroWidthCharsFlag
+	StrayBOMs           StrayBOMsFlag
+	BidiControls        BidiControlsFlag
+	Graphemes           GraphemesFlag
+	Bytes               BytesFlag
+	MaxLength           MaxLengthFlag
+	MaxLengthLine       MaxLengthLineFlag
+	LineLimit           LineLimitFlag
+	MinLength           MinLengthFlag
+	MeanLength          MeanLengthFlag
+	LengthPercentiles   LengthPercentilesFlag
+	LengthHistogram     LengthHistogramFlag
+	LengthUnit          LengthUnitFlag
+	Locale              LocaleFlag
+	EnvLocale           EnvLocaleFlag
+	Terminator          TerminatorFlag
+	UnicodeLineBreaks   UnicodeLineBreaksFlag
+	Normalization       NormalizationFlag
+	WordSplitter        WordSplitterFlag
+	SentenceRule        SentenceRuleFlag
+	Abbreviations       AbbreviationsFlag
+	SentenceLimit       SentenceLimitFlag
+	WordsPerMinute      WordsPerMinuteFlag
+	TopWords            TopWordsFlag
+	FoldCase            FoldCaseFlag
+	MinWordLength       MinWordLengthFlag
+	StopWords           StopWordsFlag
+	StopWordsFile       StopWordsFileFlag
+	FrequencyOrder      FrequencyOrderFlag
+	SpillAfter          SpillAfterFlag
+	SpillDir            SpillDirFlag
+	Precision           PrecisionFlag
+	LineSketch          LineSketchFlag
+	WordSketch          WordSketchFlag
+	Patterns            PatternFlag
+	IgnoreLines         IgnoreLinesFlag
+	OnlyLines           OnlyLinesFlag
+	SLOC                SLOCFlag
+	Language            LanguageFlag
+	GoMetrics           GoMetricsFlag
+	WhitespaceHygiene   WhitespaceHygieneFlag
+	Verbose             VerboseFlag
 }
 
-func (f LinesFlag) Configure(flags *flags)     { flags.Lines = f }
-func (f WordsFlag) Configure(flags *flags)     { flags.Words = f }
-func (f CharsFlag) Configure(flags *flags)     { flags.Chars = f }
-func (f BytesFlag) Configure(flags *flags)     { flags.Bytes = f }
-func (f MaxLengthFlag) Configure(flags *flags) { flags.MaxLength = f }
+func (f LinesFlag) Configure(flags *flags)               { flags.Lines = f }
+func (f EmptyLinesFlag) Configure(flags *flags)          { flags.EmptyLines = f }
+func (f WhitespaceLinesFlag) Configure(flags *flags)     { flags.WhitespaceLines = f }
+func (f NonBlankLinesFlag) Configure(flags *flags)       { flags.NonBlankLines = f }
+func (f UniqueLinesFlag) Configure(flags *flags)         { flags.UniqueLines = f }
+func (f DuplicateLinesFlag) Configure(flags *flags)      { flags.DuplicateLines = f }
+func (f ApproxUniqueLinesFlag) Configure(flags *flags)   { flags.ApproxUniqueLines = f }
+func (f WordsFlag) Configure(flags *flags)               { flags.Words = f }
+func (f UniqueWordsFlag) Configure(flags *flags)         { flags.UniqueWords = f }
+func (f ApproxUniqueWordsFlag) Configure(flags *flags)   { flags.ApproxUniqueWords = f }
+func (f MeanWordLengthFlag) Configure(flags *flags)      { flags.MeanWordLength = f }
+func (f LongestWordFlag) Configure(flags *flags)         { flags.LongestWord = f }
+func (f WordLengthHistogramFlag) Configure(flags *flags) { flags.WordLengthHistogram = f }
+func (f SentencesFlag) Configure(flags *flags)           { flags.Sentences = f }
+func (f ParagraphsFlag) Configure(flags *flags)          { flags.Paragraphs = f }
+func (f SyllablesFlag) Configure(flags *flags)           { flags.Syllables = f }
+func (f ReadingEaseFlag) Configure(flags *flags)         { flags.ReadingEase = f }
+func (f GradeLevelFlag) Configure(flags *flags)          { flags.GradeLevel = f }
+func (f ReadingTimeFlag) Configure(flags *flags)         { flags.ReadingTime = f }
+func (f CharsFlag) Configure(flags *flags)               { flags.Chars = f }
+func (f CharClassesFlag) Configure(flags *flags)         { flags.CharClasses = f }
+func (f ScriptsFlag) Configure(flags *flags)             { flags.Scripts = f }
+func (f BlocksFlag) Configure(flags *flags)              { flags.Blocks = f }
+func (f ZeroWidthCharsFlag) Configure(flags *flags)      { flags.ZeroWidthChars = f }
+func (f StrayBOMsFlag) Configure(flags *flags)           { flags.StrayBOMs = f }
+func (f BidiControlsFlag) Configure(flags *flags)        { flags.BidiControls = f }
+func (f GraphemesFlag) Configure(flags *flags)           { flags.Graphemes = f }
+func (f BytesFlag) Configure(flags *flags)               { flags.Bytes = f }
+func (f MaxLengthFlag) Configure(flags *flags)           { flags.MaxLength = f }
+func (f MaxLengthLineFlag) Configure(flags *flags)       { flags.MaxLengthLine = f }
+func (f LineLimitFlag) Configure(flags *flags)           { flags.LineLimit = f }
+func (f MinLengthFlag) Configure(flags *flags)           { flags.MinLength = f }
+func (f MeanLengthFlag) Configure(flags *flags)          { flags.MeanLength = f }
+func (f LengthPercentilesFlag) Configure(flags *flags)   { flags.LengthPercentiles = f }
+func (f LengthHistogramFlag) Configure(flags *flags)     { flags.LengthHistogram = f }
+func (f LengthUnitFlag) Configure(flags *flags)          { flags.LengthUnit = f }
+func (f LocaleFlag) Configure(flags *flags)              { flags.Locale = f }
+func (f EnvLocaleFlag) Configure(flags *flags)           { flags.EnvLocale = f }
+func (f TerminatorFlag) Configure(flags *flags)          { flags.Terminator = f }
+func (f UnicodeLineBreaksFlag) Configure(flags *flags)   { flags.UnicodeLineBreaks = f }
+func (f NormalizationFlag) Configure(flags *flags)       { flags.Normalization = f }
+func (f WordSplitterFlag) Configure(flags *flags)        { flags.WordSplitter = f }
+func (f SentenceRuleFlag) Configure(flags *flags)        { flags.SentenceRule = f }
+func (f AbbreviationsFlag) Configure(flags *flags)       { flags.Abbreviations = f }
+func (f SentenceLimitFlag) Configure(flags *flags)       { flags.SentenceLimit = f }
+func (f WordsPerMinuteFlag) Configure(flags *flags)      { flags.WordsPerMinute = f }
+func (f TopWordsFlag) Configure(flags *flags)            { flags.TopWords = f }
+func (f FoldCaseFlag) Configure(flags *flags)            { flags.FoldCase = f }
+func (f MinWordLengthFlag) Configure(flags *flags)       { flags.MinWordLength = f }
+func (f StopWordsFlag) Configure(flags *flags)           { flags.StopWords = f }
+func (f StopWordsFileFlag) Configure(flags *flags)       { flags.StopWordsFile = f }
+func (f FrequencyOrderFlag) Configure(flags *flags)      { flags.FrequencyOrder = f }
+func (f SpillAfterFlag) Configure(flags *flags)          { flags.SpillAfter = f }
+func (f SpillDirFlag) Configure(flags *flags)            { flags.SpillDir = f }
+func (f PrecisionFlag) Configure(flags *flags)           { flags.Precision = f }
+func (f LineSketchFlag) Configure(flags *flags)          { flags.LineSketch = f }
+func (f WordSketchFlag) Configure(flags *flags)          { flags.WordSketch = f }
+func (f PatternFlag) Configure(flags *flags)             { flags.Patterns = append(flags.Patterns, f...) }
+func (f IgnoreLinesFlag) Configure(flags *flags)         { flags.IgnoreLines = append(flags.IgnoreLines, f...) }
+func (f OnlyLinesFlag) Configure(flags *flags)           { flags.OnlyLines = append(flags.OnlyLines, f...) }
+func (f SLOCFlag) Configure(flags *flags)                { flags.SLOC = f }
+func (f LanguageFlag) Configure(flags *flags)            { flags.Language = f }
+func (f GoMetricsFlag) Configure(flags *flags)           { flags.GoMetrics = f }
+func (f WhitespaceHygieneFlag) Configure(flags *flags)   { flags.WhitespaceHygiene = f }
+func (f VerboseFlag) Configure(flags *flags)             { flags.Verbose = f }
@@ -0,0 +1,174 @@
+package command
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ahoCorasickThreshold is the number of fixed strings from which one
+// Aho-Corasick pass beats scanning the line once per string.
+const ahoCorasickThreshold = 8
+
+// patternSpec is one pattern given to Pattern or FixedStrings.
+type patternSpec struct {
+	expr  string
+	fixed bool
+}
+
+// patterns counts matching lines and non-overlapping matches per pattern.
+type patterns struct {
+	regexps   []*regexp.Regexp // by pattern index; nil for fixed strings
+	fixed     []string         // by pattern index, when not using automaton
+	automaton *ahoCorasick
+
+	lines, matches []int
+}
+
+func newPatterns(specs []patternSpec) (*patterns, error) {
+	p := &patterns{
+		regexps: make([]*regexp.Regexp, len(specs)),
+		fixed:   make([]string, len(specs)),
+		lines:   make([]int, len(specs)),
+		matches: make([]int, len(specs)),
+	}
+
+	var fixedCount int
+	for _, spec := range specs {
+		if spec.fixed {
+			fixedCount++
+		}
+	}
+	if fixedCount >= ahoCorasickThreshold {
+		p.automaton = newAhoCorasick(len(specs))
+	}
+
+	for i, spec := range specs {
+		switch {
+		case !spec.fixed:
+			re, err := regexp.Compile(spec.expr)
+			if err != nil {
+				return nil, err
+			}
+			p.regexps[i] = re
+		case spec.expr == "":
+			return nil, errors.New("empty fixed string pattern")
+		case p.automaton != nil:
+			p.automaton.add(spec.expr, i)
+		default:
+			p.fixed[i] = spec.expr
+		}
+	}
+	if p.automaton != nil {
+		p.automaton.build()
+	}
+	return p, nil
+}
+
+func (p *patterns) add(line string) {
+	found := make([]int, len(p.lines))
+	if p.automaton != nil {
+		p.automaton.count(line, found)
+	}
+	for i := range found {
+		switch {
+		case p.regexps[i] != nil:
+			found[i] = len(p.regexps[i].FindAllStringIndex(line, -1))
+		case p.fixed[i] != "":
+			found[i] = strings.Count(line, p.fixed[i])
+		}
+	}
+	for i, n := range found {
+		if n > 0 {
+			p.lines[i]++
+			p.matches[i] += n
+		}
+	}
+}
+
+// ahoCorasick matches a set of fixed strings in one pass over the input.
+type ahoCorasick struct {
+	nodes    []acNode
+	patterns []int // pattern lengths by pattern index; 0 if not in the set
+}
+
+type acNode struct {
+	next   map[byte]int32
+	fail   int32
+	output []int // pattern indexes ending here, including via suffix links
+}
+
+func newAhoCorasick(patterns int) *ahoCorasick {
+	return &ahoCorasick{
+		nodes:    []acNode{{next: map[byte]int32{}}},
+		patterns: make([]int, patterns),
+	}
+}
+
+func (a *ahoCorasick) add(s string, index int) {
+	node := int32(0)
+	for i := 0; i < len(s); i++ {
+		next, ok := a.nodes[node].next[s[i]]
+		if !ok {
+			next = int32(len(a.nodes))
+			a.nodes = append(a.nodes, acNode{next: map[byte]int32{}})
+			a.nodes[node].next[s[i]] = next
+		}
+		node = next
+	}
+	a.nodes[node].output = append(a.nodes[node].output, index)
+	a.patterns[index] = len(s)
+}
+
+// build computes failure links breadth-first and merges outputs along them.
+func (a *ahoCorasick) build() {
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for b, child := range a.nodes[node].next {
+			fail := a.nodes[node].fail
+			for {
+				if next, ok := a.nodes[fail].next[b]; ok {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+			target := a.nodes[child].fail
+			a.nodes[child].output = append(a.nodes[child].output, a.nodes[target].output...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// count adds the non-overlapping matches of each pattern in line to found,
+// taking leftmost matches first like strings.Count.
+func (a *ahoCorasick) count(line string, found []int) {
+	nextFree := make([]int, len(found)) // first offset available per pattern
+	node := int32(0)
+	for i := 0; i < len(line); i++ {
+		for {
+			if next, ok := a.nodes[node].next[line[i]]; ok {
+				node = next
+				break
+			}
+			if node == 0 {
+				break
+			}
+			node = a.nodes[node].fail
+		}
+		for _, index := range a.nodes[node].output {
+			if start := i + 1 - a.patterns[index]; start >= nextFree[index] {
+				found[index]++
+				nextFree[index] = i + 1
+			}
+		}
+	}
+}
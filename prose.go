@@ -0,0 +1,129 @@
+package command
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// defaultAbbreviations do not end a sentence under PunctuationSentences.
+var defaultAbbreviations = []string{
+	"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "vs", "etc",
+	"e.g", "i.e", "cf", "fig", "no", "approx", "inc", "ltd", "co",
+}
+
+// prose tracks paragraphs and the sentences within them. A paragraph is a
+// run of non-blank lines; its text is segmented when a blank line or the end
+// of input closes it, so sentences may span line breaks.
+type prose struct {
+	rule          SentenceRuleFlag
+	abbreviations map[string]bool
+	limit         int
+
+	text                                  strings.Builder
+	sentences, paragraphs, longParagraphs int
+}
+
+func newProse(f flags) *prose {
+	abbreviations := defaultAbbreviations
+	if f.Abbreviations != nil {
+		abbreviations = f.Abbreviations
+	}
+	p := &prose{
+		rule:          f.SentenceRule,
+		abbreviations: make(map[string]bool, len(abbreviations)),
+		limit:         int(f.SentenceLimit),
+	}
+	for _, abbreviation := range abbreviations {
+		p.abbreviations[normalizeAbbreviation(abbreviation)] = true
+	}
+	return p
+}
+
+func (p *prose) add(line string) {
+	if strings.TrimSpace(line) == "" {
+		p.flush()
+		return
+	}
+	if p.text.Len() > 0 {
+		p.text.WriteByte(' ')
+	}
+	p.text.WriteString(line)
+}
+
+// flush closes the current paragraph, if any.
+func (p *prose) flush() {
+	if p.text.Len() == 0 {
+		return
+	}
+	var sentences int
+	if p.rule == PunctuationSentences {
+		sentences = p.punctuatedSentences(p.text.String())
+	} else {
+		sentences = unicodeSentences(p.text.String())
+	}
+	p.text.Reset()
+
+	p.paragraphs++
+	p.sentences += sentences
+	if p.limit > 0 && sentences > p.limit {
+		p.longParagraphs++
+	}
+}
+
+// unicodeSentences counts UAX #29 sentences containing a letter or number.
+func unicodeSentences(text string) int {
+	count, state := 0, -1
+	for len(text) > 0 {
+		var sentence string
+		sentence, text, state = uniseg.FirstSentenceInString(text, state)
+		if isWordLike(sentence) {
+			count++
+		}
+	}
+	return count
+}
+
+// punctuatedSentences ends a sentence at '.', '!', '?' or '…' followed by
+// whitespace, unless the word is a known abbreviation, and at CJK full stops
+// wherever they appear.
+func (p *prose) punctuatedSentences(text string) int {
+	count, content := 0, false
+	end := func() {
+		if content {
+			count++
+		}
+		content = false
+	}
+	for _, field := range strings.Fields(text) {
+		for _, r := range field {
+			switch {
+			case r == '。' || r == '！' || r == '？':
+				end()
+			case unicode.IsLetter(r) || unicode.IsNumber(r):
+				content = true
+			}
+		}
+		word := strings.TrimRight(field, "\"')]}»”’")
+		last, _ := utf8.DecodeLastRuneInString(word)
+		switch last {
+		case '.':
+			if !p.abbreviations[normalizeAbbreviation(word)] {
+				end()
+			}
+		case '!', '?', '…':
+			end()
+		}
+	}
+	end()
+	return count
+}
+
+// normalizeAbbreviation folds case and surrounding punctuation so that
+// "e.g." in the text matches "e.g" in the list.
+func normalizeAbbreviation(word string) string {
+	word = strings.TrimLeft(word, "\"'([{«“‘")
+	return strings.ToLower(strings.TrimRight(word, "."))
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultWordsPerMinute is the average silent reading rate for English
+// non-fiction reported by Brysbaert (2019).
+const defaultWordsPerMinute = 238
+
+// syllables estimates the syllables in an English word by counting vowel
+// groups, discounting a silent final "e". Words with letters have at least
+// one syllable; words without letters have none.
+func syllables(word string) int {
+	count, vowelRun, letters := 0, false, 0
+	var last, beforeLast rune
+	for _, r := range strings.ToLower(word) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		isVowel := strings.ContainsRune("aeiouy", r)
+		if isVowel && !vowelRun {
+			count++
+		}
+		vowelRun = isVowel
+		beforeLast, last = last, r
+	}
+	if letters == 0 {
+		return 0
+	}
+	// "make" drops the final e, but "table" keeps its "le" syllable.
+	if last == 'e' && count > 1 && beforeLast != 'l' && !strings.ContainsRune("aeiouy", beforeLast) {
+		count--
+	}
+	return max(count, 1)
+}
+
+// readingEase is the Flesch reading ease score.
+func readingEase(words, sentences, syllables int) float64 {
+	if words == 0 || sentences == 0 {
+		return 0
+	}
+	return 206.835 - 1.015*float64(words)/float64(sentences) - 84.6*float64(syllables)/float64(words)
+}
+
+// gradeLevel is the Flesch-Kincaid grade level.
+func gradeLevel(words, sentences, syllables int) float64 {
+	if words == 0 || sentences == 0 {
+		return 0
+	}
+	return 0.39*float64(words)/float64(sentences) + 11.8*float64(syllables)/float64(words) - 15.59
+}
+
+// readingTime is the estimated reading time in minutes.
+func readingTime(words int, wordsPerMinute WordsPerMinuteFlag) float64 {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = defaultWordsPerMinute
+	}
+	return float64(words) / float64(wordsPerMinute)
+}
+
+// score formats a metric with one decimal place.
+func score(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
+
+// readability reports whether a score needing sentence counts is selected.
+func (f flags) readability() bool {
+	return bool(f.ReadingEase) || bool(f.GradeLevel)
+}
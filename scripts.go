@@ -0,0 +1,125 @@
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// unknownScript names characters in no script, and invalid bytes.
+	unknownScript = "Unknown"
+	// noBlock names characters outside every block, and invalid bytes.
+	noBlock = "No_Block"
+)
+
+// scriptNames are the names in unicode.Scripts, sorted so lookups are
+// deterministic.
+var scriptNames = slices.Sorted(maps.Keys(unicode.Scripts))
+
+// scriptOf returns the Unicode script of r.
+func scriptOf(r rune) string {
+	for _, name := range scriptNames {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return unknownScript
+}
+
+//go:generate go run blocks_gen.go
+
+// unicodeBlock is a range of code points from Blocks.txt.
+type unicodeBlock struct {
+	first, last rune
+	name        string
+}
+
+// blockOf returns the Unicode block of r.
+func blockOf(r rune) string {
+	i, found := slices.BinarySearchFunc(unicodeBlocks, r, func(b unicodeBlock, r rune) int {
+		switch {
+		case r < b.first:
+			return 1
+		case r > b.last:
+			return -1
+		}
+		return 0
+	})
+	if !found {
+		return noBlock
+	}
+	return unicodeBlocks[i].name
+}
+
+// charTally counts characters per name, such as their script for Scripts
+// or their block for Blocks.
+type charTally struct {
+	kind    string // "script" or "block", printed on each line
+	lookup  func(rune) string
+	invalid string // name for invalid bytes, and bytes above 0x7F in the C locale
+	counts  map[string]int
+	total   int
+	cache   map[rune]string
+}
+
+func newCharTally(kind string, lookup func(rune) string, invalid string) *charTally {
+	return &charTally{kind: kind, lookup: lookup, invalid: invalid, counts: map[string]int{}, cache: map[rune]string{}}
+}
+
+// name returns the name of r, remembering it since text rarely uses more
+// than a few hundred distinct characters.
+func (t *charTally) name(r rune) string {
+	name, ok := t.cache[r]
+	if !ok {
+		name = t.lookup(r)
+		t.cache[r] = name
+	}
+	return name
+}
+
+// add counts the characters of line.
+func (t *charTally) add(line string, locale LocaleFlag) {
+	if locale == LocaleC {
+		for i := 0; i < len(line); i++ {
+			if line[i] < utf8.RuneSelf {
+				t.counts[t.name(rune(line[i]))]++
+			} else {
+				t.counts[t.invalid]++
+			}
+		}
+		t.total += len(line)
+		return
+	}
+	for len(line) > 0 {
+		r, size := utf8.DecodeRuneInString(line)
+		line = line[size:]
+		if r == utf8.RuneError && size == 1 {
+			t.counts[t.invalid]++
+		} else {
+			t.counts[t.name(r)]++
+		}
+		t.total++
+	}
+}
+
+// write prints one "count percent kind name" line per name, most
+// characters first, so script and block tables stay apart when both are
+// printed.
+func (t *charTally) write(w io.Writer) error {
+	names := slices.Collect(maps.Keys(t.counts))
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(t.counts[b], t.counts[a]), cmp.Compare(a, b))
+	})
+	for _, name := range names {
+		percent := 100 * float64(t.counts[name]) / float64(t.total)
+		if _, err := fmt.Fprintf(w, "%7d %5.1f%% %-6s %s\n", t.counts[name], percent, t.kind, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
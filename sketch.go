@@ -0,0 +1,186 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	MinPrecision     = 4
+	MaxPrecision     = 18
+	defaultPrecision = 14
+
+	sketchVersion = 1
+)
+
+// Sketch is a HyperLogLog estimator of the number of distinct values. It
+// uses 2^precision one-byte registers and has a relative standard error of
+// 1.04/sqrt(2^precision), about 0.8% at the default precision of 14.
+//
+// Sketches with the same precision can be merged, so sketches built from
+// separate inputs combine into the estimate for their union, and they
+// round-trip through MarshalBinary and UnmarshalBinary.
+//
+// The zero value is an empty sketch of the default precision, 14, unless
+// it is first merged into, when it takes the precision of the other sketch.
+type Sketch struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewSketch returns an empty Sketch. Precision must be between MinPrecision
+// and MaxPrecision.
+func NewSketch(precision int) (*Sketch, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, fmt.Errorf("sketch precision %d out of range [%d, %d]", precision, MinPrecision, MaxPrecision)
+	}
+	return &Sketch{precision: uint8(precision), registers: make([]uint8, 1<<precision)}, nil
+}
+
+// init allocates the registers of a zero Sketch at precision.
+func (s *Sketch) init(precision uint8) {
+	if s.registers == nil {
+		s.precision = precision
+		s.registers = make([]uint8, 1<<precision)
+	}
+}
+
+// Precision returns the number of index bits.
+func (s *Sketch) Precision() int {
+	s.init(defaultPrecision)
+	return int(s.precision)
+}
+
+// Add records value.
+func (s *Sketch) Add(value string) {
+	s.init(defaultPrecision)
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	hash := mix64(h.Sum64())
+
+	index := hash >> (64 - s.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<s.precision|1<<(s.precision-1)) + 1)
+	if rank > s.registers[index] {
+		s.registers[index] = rank
+	}
+}
+
+// mix64 is the MurmurHash3 finalizer; it spreads FNV output over all bits.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// Estimate returns the estimated number of distinct values added.
+func (s *Sketch) Estimate() float64 {
+	s.init(defaultPrecision)
+	m := float64(len(s.registers))
+	var sum float64
+	zeros := 0
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(s.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Small-range correction: linear counting.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+// StandardError returns the absolute standard error of Estimate.
+func (s *Sketch) StandardError() float64 {
+	s.init(defaultPrecision)
+	return s.Estimate() * 1.04 / math.Sqrt(float64(len(s.registers)))
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+// Merge folds other into s, so s estimates the union of both inputs.
+func (s *Sketch) Merge(other *Sketch) error {
+	if other == nil {
+		return errors.New("cannot merge a nil sketch")
+	}
+	if other.registers == nil {
+		// An unused zero Sketch is empty at any precision.
+		s.init(defaultPrecision)
+		return nil
+	}
+	s.init(other.precision)
+	if s.precision != other.precision {
+		return fmt.Errorf("cannot merge sketches of precision %d and %d", s.precision, other.precision)
+	}
+	for i, r := range other.registers {
+		s.registers[i] = max(s.registers[i], r)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the sketch as a version byte, the precision and
+// the registers.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	s.init(defaultPrecision)
+	return append([]byte{sketchVersion, s.precision}, s.registers...), nil
+}
+
+// UnmarshalBinary decodes a sketch written by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != sketchVersion {
+		return errors.New("unrecognised sketch encoding")
+	}
+	decoded, err := NewSketch(int(data[1]))
+	if err != nil {
+		return err
+	}
+	if len(data)-2 != len(decoded.registers) {
+		return fmt.Errorf("sketch has %d registers, want %d", len(data)-2, len(decoded.registers))
+	}
+	copy(decoded.registers, data[2:])
+	*s = *decoded
+	return nil
+}
+
+// sketch returns the caller's sketch if one was given, a new sketch if
+// enabled, or nil.
+func (f flags) sketch(given *Sketch, enabled bool) (*Sketch, error) {
+	if given != nil {
+		return given, nil
+	}
+	if !enabled {
+		return nil, nil
+	}
+	precision := int(f.Precision)
+	if precision == 0 {
+		precision = defaultPrecision
+	}
+	return NewSketch(precision)
+}
+
+// approximate formats a sketch estimate and its standard error as columns.
+func approximate(s *Sketch) (estimate, standardError string) {
+	if s == nil {
+		return count(0), count(0)
+	}
+	return count(int(math.Round(s.Estimate()))), count(int(math.Round(s.StandardError())))
+}
@@ -0,0 +1,265 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	gloo "github.com/gloo-foo/framework"
+)
+
+type slocKind int
+
+const (
+	slocBlank slocKind = iota
+	slocComment
+	slocCode
+)
+
+// slocCounts are the blank, comment and code line totals for one or more
+// files.
+type slocCounts struct {
+	files, blank, comment, code int
+}
+
+func (s *slocCounts) add(o slocCounts) {
+	s.files += o.files
+	s.blank += o.blank
+	s.comment += o.comment
+	s.code += o.code
+}
+
+// slocFile is the result for a single input.
+type slocFile struct {
+	name     string
+	language string
+	slocCounts
+}
+
+// slocExecutor counts each input on its own, since lines can only be
+// classified once the language of their file is known.
+func (p command) slocExecutor() gloo.CommandExecutor {
+	return func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer) error {
+		forced, err := p.Flags.language()
+		if err != nil {
+			return fmt.Errorf("sloc: %w", err)
+		}
+
+		named, err := p.namedInputs(stdin)
+		if err != nil {
+			return fmt.Errorf("sloc: %w", err)
+		}
+		var files []slocFile
+		for _, input := range named {
+			file, ok, err := countSLOC(p.Flags, input.name, forced, input.reader)
+			if err != nil {
+				return err
+			}
+			if ok {
+				files = append(files, file)
+			}
+		}
+		return writeSLOC(stdout, files)
+	}
+}
+
+// countSLOC classifies the lines of r. Inputs in no known language are
+// reported as not ok and left out, as cloc does.
+func countSLOC(f flags, name string, lang *language, r io.Reader) (slocFile, bool, error) {
+	if lang == nil && name != "-" {
+		lang = languageForPath(name)
+	}
+	scanner, _ := newRecordScanner(r, f)
+
+	file := slocFile{name: name, slocCounts: slocCounts{files: 1}}
+	var classifier *slocClassifier
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		if first {
+			shebang := strings.HasPrefix(line, "#!")
+			if lang == nil && shebang {
+				lang = languageForShebang(line)
+			}
+			if lang == nil {
+				return slocFile{}, false, nil
+			}
+			classifier = &slocClassifier{lang: lang}
+			if shebang {
+				file.comment++
+				continue
+			}
+		}
+		switch classifier.classify(line) {
+		case slocBlank:
+			file.blank++
+		case slocComment:
+			file.comment++
+		case slocCode:
+			file.code++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return slocFile{}, false, err
+	}
+	if lang == nil {
+		return slocFile{}, false, nil
+	}
+	file.language = lang.name
+	return file, true, nil
+}
+
+// writeSLOC prints one row per file, one per language ordered by code
+// lines, and a total, each as files, blank, comment and code counts.
+func writeSLOC(w io.Writer, files []slocFile) error {
+	byLanguage := map[string]*slocCounts{}
+	var total slocCounts
+	for _, file := range files {
+		if byLanguage[file.language] == nil {
+			byLanguage[file.language] = &slocCounts{}
+		}
+		byLanguage[file.language].add(file.slocCounts)
+		total.add(file.slocCounts)
+	}
+	names := make([]string, 0, len(byLanguage))
+	for name := range byLanguage {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := byLanguage[names[i]], byLanguage[names[j]]
+		if a.code != b.code {
+			return a.code > b.code
+		}
+		return names[i] < names[j]
+	})
+
+	row := func(c slocCounts, name string) error {
+		_, err := fmt.Fprintf(w, "%7d %7d %7d %7d %s\n", c.files, c.blank, c.comment, c.code, name)
+		return err
+	}
+	for _, file := range files {
+		if err := row(file.slocCounts, file.name); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		if err := row(*byLanguage[name], name); err != nil {
+			return err
+		}
+	}
+	return row(total, "total")
+}
+
+// slocClassifier carries open block comments and multi-line strings from
+// one line to the next.
+type slocClassifier struct {
+	lang      *language
+	depth     int
+	block     delimiters
+	str       *stringSyntax
+	docstring bool // str opened a line, so it is documentation
+}
+
+// classify reports whether line is blank, only comment, or has code.
+// Comment markers inside string literals are code. In languages with
+// docstrings, a multi-line string opening a line is a comment, as cloc
+// counts it.
+func (c *slocClassifier) classify(line string) slocKind {
+	if strings.TrimFunc(line, isASCIISpace) == "" {
+		return slocBlank
+	}
+	code, comment := false, false
+	for i := 0; i < len(line); {
+		rest := line[i:]
+		switch {
+		case c.depth > 0:
+			comment = true
+			switch {
+			case c.lang.nested && strings.HasPrefix(rest, c.block.open):
+				c.depth++
+				i += len(c.block.open)
+			case strings.HasPrefix(rest, c.block.close):
+				c.depth--
+				i += len(c.block.close)
+			default:
+				i++
+			}
+		case c.str != nil:
+			if c.docstring {
+				comment = true
+			} else {
+				code = true
+			}
+			switch {
+			case c.str.escapes && rest[0] == '\\':
+				i += 2
+			case strings.HasPrefix(rest, c.str.close):
+				i += len(c.str.close)
+				c.str, c.docstring = nil, false
+			default:
+				i++
+			}
+		case isASCIISpace(rune(rest[0])):
+			i++
+		default:
+			if block, ok := c.lang.blockCommentAt(rest); ok {
+				comment = true
+				c.block, c.depth = block, 1
+				i += len(block.open)
+			} else if c.lang.lineCommentAt(rest) {
+				comment = true
+				i = len(line)
+			} else if str := c.lang.stringAt(rest); str != nil {
+				c.str = str
+				c.docstring = c.lang.docstrings && str.multiline && !code
+				if c.docstring {
+					comment = true
+				} else {
+					code = true
+				}
+				i += len(str.open)
+			} else {
+				code = true
+				i++
+			}
+		}
+	}
+	if c.str != nil && !c.str.multiline {
+		c.str = nil
+	}
+	switch {
+	case code:
+		return slocCode
+	case comment:
+		return slocComment
+	}
+	return slocBlank
+}
+
+func (l *language) blockCommentAt(s string) (delimiters, bool) {
+	for _, block := range l.blockComments {
+		if strings.HasPrefix(s, block.open) {
+			return block, true
+		}
+	}
+	return delimiters{}, false
+}
+
+func (l *language) lineCommentAt(s string) bool {
+	for _, marker := range l.lineComments {
+		if strings.HasPrefix(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *language) stringAt(s string) *stringSyntax {
+	for i := range l.strings {
+		if strings.HasPrefix(s, l.strings[i].open) {
+			return &l.strings[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"unicode/utf8"
+)
+
+// separator returns the byte sequence that ends a record.
+func (t TerminatorFlag) separator() string {
+	if t == "" {
+		return string(LF)
+	}
+	return string(t)
+}
+
+// recordSplitter splits input into records and remembers the length of the
+// terminator that ended the most recent one.
+type recordSplitter struct {
+	// find returns the position and length of the first terminator in data.
+	// more reports that the result may change once more data is available.
+	find func(data []byte) (at, length int, more bool)
+	// last is the terminator length of the record most recently returned.
+	last int
+	// unterminated reports that the final record had no terminator.
+	unterminated bool
+}
+
+func newRecordSplitter(f flags) *recordSplitter {
+	if bool(f.UnicodeLineBreaks) {
+		return &recordSplitter{find: findMandatoryBreak}
+	}
+	sep := []byte(f.Terminator.separator())
+	return &recordSplitter{
+		find: func(data []byte) (int, int, bool) {
+			return bytes.Index(data, sep), len(sep), false
+		},
+	}
+}
+
+// newRecordScanner returns a scanner over r that yields records of any
+// length, and the splitter that reports their terminators.
+func newRecordScanner(r io.Reader, f flags) (*bufio.Scanner, *recordSplitter) {
+	records := newRecordSplitter(f)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), math.MaxInt)
+	scanner.Split(records.split)
+	return scanner, records
+}
+
+// split is a bufio.SplitFunc yielding records without their terminator.
+// A trailing record with no terminator is still returned, with a
+// terminator length of zero.
+func (s *recordSplitter) split(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if at, length, more := s.find(data); at >= 0 && (atEOF || !more) {
+		s.last = length
+		return at + length, data[:at], nil
+	}
+	if atEOF {
+		s.last, s.unterminated = 0, true
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// findMandatoryBreak locates the first UAX #14 mandatory break: the BK class
+// (VT, FF, U+2028, U+2029), CR, LF, NEL, and CR LF as a single break.
+func findMandatoryBreak(data []byte) (at, length int, more bool) {
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		switch r {
+		case '\r':
+			if i+1 == len(data) {
+				return i, 1, true
+			}
+			if data[i+1] == '\n' {
+				return i, 2, false
+			}
+			return i, 1, false
+		case '\n', '\v', '\f', '\u0085', '\u2028', '\u2029':
+			return i, size, false
+		}
+		i += size
+	}
+	return -1, 0, false
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"strconv"
+	"strings"
+)
+
+// wordLengths tracks word lengths in characters for MeanWordLength,
+// LongestWord and WordLengthHistogram.
+type wordLengths struct {
+	stats *lengthStats
+
+	longest                    string
+	longestLength, longestLine int
+}
+
+func newWordLengths() *wordLengths {
+	return &wordLengths{stats: newLengthStats()}
+}
+
+// add records the words of line number; the first longest word wins ties.
+func (w *wordLengths) add(number int, words []string, locale LocaleFlag, bucketWidth int) {
+	for _, word := range words {
+		length := locale.countChars(word)
+		w.stats.add(length, bucketWidth)
+		if length > w.longestLength {
+			w.longest, w.longestLength, w.longestLine = word, length, number
+		}
+	}
+}
+
+// quoted formats the longest word as a Go string literal with spaces
+// escaped as \x20, so words from SeparatorSet or TokenPattern that contain
+// spaces or control characters stay one column.
+func (w *wordLengths) quoted() string {
+	return strings.ReplaceAll(strconv.Quote(w.longest), " ", `\x20`)
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// WordSplitter breaks a line into the words that Words counts.
+type WordSplitter interface {
+	Split(line string) []string
+}
+
+// WordSplitterFunc adapts an ordinary function to a WordSplitter.
+type WordSplitterFunc func(line string) []string
+
+func (f WordSplitterFunc) Split(line string) []string { return f(line) }
+
+// WordSplitterFlag selects the WordSplitter used for Words. The zero value
+// splits on whitespace according to the locale.
+type WordSplitterFlag struct{ WordSplitter }
+
+// SplitWords returns a WordSplitterFlag for a custom splitter.
+func SplitWords(s WordSplitter) WordSplitterFlag { return WordSplitterFlag{s} }
+
+var (
+	// WhitespaceWords splits on any Unicode whitespace, like strings.Fields.
+	WhitespaceWords = SplitWords(WordSplitterFunc(strings.Fields))
+	// AlphanumericWords keeps runs of letters, digits and combining marks;
+	// everything else separates words, so "foo-bar" is two words.
+	AlphanumericWords = SplitWords(WordSplitterFunc(func(line string) []string {
+		return strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+		})
+	}))
+)
+
+// UnicodeWords segments text at UAX #29 word boundaries and keeps the
+// segments containing a letter or number. Punctuation-only segments are
+// dropped, while "don't", "3.14" and "1,000" stay single words.
+var UnicodeWords = SplitWords(WordSplitterFunc(func(line string) []string {
+	var words []string
+	state := -1
+	for len(line) > 0 {
+		var segment string
+		segment, line, state = uniseg.FirstWordInString(line, state)
+		if isWordLike(segment) {
+			words = append(words, segment)
+		}
+	}
+	return words
+}))
+
+// isWordLike reports whether a word-boundary segment is a word rather than
+// whitespace, punctuation or symbols.
+func isWordLike(segment string) bool {
+	return strings.IndexFunc(segment, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) >= 0
+}
+
+// TokenPattern counts every non-empty match of re as a word.
+func TokenPattern(re *regexp.Regexp) WordSplitterFlag {
+	return SplitWords(WordSplitterFunc(func(line string) []string {
+		var words []string
+		for _, match := range re.FindAllString(line, -1) {
+			if match != "" {
+				words = append(words, match)
+			}
+		}
+		return words
+	}))
+}
+
+// SeparatorSet splits on any rune in separators and nothing else; include
+// " \t" to keep splitting on blanks as well.
+func SeparatorSet(separators string) WordSplitterFlag {
+	return SplitWords(WordSplitterFunc(func(line string) []string {
+		return strings.FieldsFunc(line, func(r rune) bool {
+			return strings.ContainsRune(separators, r)
+		})
+	}))
+}
+
+// asciiWhitespaceWords is the default splitter in the C locale.
+var asciiWhitespaceWords = WordSplitterFunc(func(line string) []string {
+	return strings.FieldsFunc(line, isASCIISpace)
+})
+
+// wordSplitter returns the configured splitter or the locale default.
+func (f flags) wordSplitter(locale LocaleFlag) WordSplitter {
+	if f.WordSplitter.WordSplitter != nil {
+		return f.WordSplitter
+	}
+	if locale == LocaleC {
+		return asciiWhitespaceWords
+	}
+	return WhitespaceWords
+}